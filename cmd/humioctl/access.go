@@ -0,0 +1,14 @@
+package main
+
+import "github.com/spf13/cobra"
+
+func newAccessCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "access",
+		Short: "Audit user access",
+	}
+
+	cmd.AddCommand(newAccessReportCmd())
+
+	return cmd
+}
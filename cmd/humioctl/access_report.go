@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newAccessReportCmd() *cobra.Command {
+	var matrix bool
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report which users can access which repositories and views.",
+		Long: `Report the effective permissions of every user on every repository and view, and what grants them.
+
+Permissions are granted to root users, through roles assigned directly to users for a repository or view,
+and through the roles assigned to groups. Organization and system permissions from group roles are
+reported on the views "(organization)" and "(system)".
+Read access to a view also gives read access to the data of the repositories it connects to.
+
+Use --format csv or --format json to export the report, e.g.
+
+  $ humioctl access report --format csv > access.csv
+
+Use --matrix to report one row per user and one column per repository or view instead,
+with the permissions of the user in each cell, e.g.
+
+  $ humioctl access report --matrix --format csv > access-matrix.csv
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			report, err := client.AccessReport(context.Background(), bulkOptions(cmd))
+			exitOnError(cmd, err, "Error building access report")
+
+			if matrix {
+				columns, rows := accessMatrix(report)
+				printOverviewTable(cmd, columns, rows)
+				return
+			}
+
+			rows := make([][]format.Value, len(report))
+			for i, grant := range report {
+				rows[i] = []format.Value{
+					format.String(grant.Username),
+					format.String(grant.View),
+					format.String(strings.Join(grant.Permissions, ", ")),
+					format.String(strings.Join(grant.GrantedBy, "; ")),
+				}
+			}
+
+			printOverviewTable(cmd, []string{"User", "View", "Permissions", "Granted By"}, rows)
+		},
+	}
+
+	cmd.Flags().BoolVar(&matrix, "matrix", false, "Report the permissions as a matrix of users and views.")
+
+	return cmd
+}
+
+// accessMatrix returns the columns and rows of a report with a row for each user and a column for each view.
+func accessMatrix(report []api.AccessGrant) ([]string, [][]format.Value) {
+	var users, views []string
+	userIndex := map[string]int{}
+	viewIndex := map[string]int{}
+	for _, grant := range report {
+		if _, ok := userIndex[grant.Username]; !ok {
+			userIndex[grant.Username] = len(users)
+			users = append(users, grant.Username)
+		}
+		if _, ok := viewIndex[grant.View]; !ok {
+			viewIndex[grant.View] = 0
+			views = append(views, grant.View)
+		}
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return strings.ToLower(views[i]) < strings.ToLower(views[j])
+	})
+	for i, view := range views {
+		viewIndex[view] = i + 1
+	}
+
+	rows := make([][]format.Value, len(users))
+	for i, username := range users {
+		rows[i] = make([]format.Value, len(views)+1)
+		rows[i][0] = format.String(username)
+		for j := range views {
+			rows[i][j+1] = format.String("")
+		}
+	}
+	for _, grant := range report {
+		rows[userIndex[grant.Username]][viewIndex[grant.View]] = format.String(strings.Join(grant.Permissions, ", "))
+	}
+
+	return append([]string{"User"}, views...), rows
+}
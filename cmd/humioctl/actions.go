@@ -30,6 +30,8 @@ func newActionsCmd() *cobra.Command {
 	cmd.AddCommand(newActionsInstallCmd())
 	cmd.AddCommand(newActionsExportCmd())
 	cmd.AddCommand(newActionsExportAllCmd())
+	cmd.AddCommand(newActionsTestCmd())
+	cmd.AddCommand(newActionsRenderCmd())
 
 	return cmd
 }
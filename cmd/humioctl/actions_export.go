@@ -15,20 +15,89 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/humio/cli/internal/api"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 )
 
+// actionSecretsFlags controls how the secret fields of exported actions are written.
+type actionSecretsFlags struct {
+	mode string
+	dir  string
+}
+
+func (f *actionSecretsFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.mode, "secrets", "env", "How secret fields are exported: env replaces them with ${ENV_VAR} references, file writes them to files in a subdirectory of --secrets-dir per view and replaces them with ${file:path} references, and plain keeps them as is.")
+	cmd.Flags().StringVar(&f.dir, "secrets-dir", "secrets", "The directory secrets are written to when using --secrets=file.")
+}
+
+// replaceSecrets replaces the secret fields of the action in the repository or view with references, as selected by the flags.
+// File references are written relative to the directory of the exported action file at outFilePath,
+// which is where the install command resolves them from.
+func (f *actionSecretsFlags) replaceSecrets(action *api.Action, searchDomain, outFilePath string) error {
+	switch f.mode {
+	case "plain":
+		return nil
+	case "env":
+		for _, secret := range action.Secrets() {
+			*secret.Value = "${" + api.SecretEnvVarName(searchDomain, action.Name, secret.Field) + "}"
+		}
+		return nil
+	case "file":
+		dir := filepath.Join(f.dir, sanitizeTriggerName(searchDomain))
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		for _, secret := range action.Secrets() {
+			path := filepath.Join(dir, sanitizeTriggerName(action.Name)+"_"+sanitizeTriggerName(secret.Field))
+			if err := os.WriteFile(path, []byte(*secret.Value+"\n"), 0600); err != nil {
+				return err
+			}
+			reference, err := relativePath(filepath.Dir(outFilePath), path)
+			if err != nil {
+				return err
+			}
+			*secret.Value = "${file:" + reference + "}"
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported --secrets %q, must be env, file or plain", f.mode)
+	}
+}
+
+// relativePath returns the path of target relative to the directory dir.
+func relativePath(dir, target string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(absDir, absTarget)
+}
+
 func newActionsExportCmd() *cobra.Command {
 	var outputName string
+	var secrets actionSecretsFlags
 
 	cmd := cobra.Command{
 		Use:   "export [flags] <repo-or-view> <action>",
 		Short: "Export an action <action> in <repo-or-view> to a file.",
-		Args:  cobra.ExactArgs(2),
+		Long: `Export an action <action> in <repo-or-view> to a file.
+
+Secret fields, such as API keys, tokens, Slack webhook URLs and webhook headers, are replaced with references
+so the file can be committed. By default a reference to an environment variable named after the repository or view,
+the action and the field is written, e.g. ${LOGS_NOTIFY_GENIE_KEY}. With --secrets=file, the secrets are written to
+<secrets-dir>/<sanitized-view-name>/ and referred to by paths relative to the exported action file.
+The install command resolves the references.`,
+		Args: cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			repoOrViewName := args[0]
 			actionName := args[1]
@@ -41,55 +110,71 @@ func newActionsExportCmd() *cobra.Command {
 			action, err := client.Actions().Get(repoOrViewName, actionName)
 			exitOnError(cmd, err, "Error fetching action")
 
+			outFilePath := outputName + ".yaml"
+			err = secrets.replaceSecrets(action, repoOrViewName, outFilePath)
+			exitOnError(cmd, err, "Error exporting the action secrets")
+
 			yamlData, err := yaml.Marshal(&action)
 			exitOnError(cmd, err, "Failed to serialize the action")
 
-			outFilePath := outputName + ".yaml"
 			err = os.WriteFile(outFilePath, yamlData, 0600)
 			exitOnError(cmd, err, "Error saving the action file")
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputName, "output", "o", "", "The file path where the action should be written. Defaults to ./<action-name>.yaml")
+	secrets.register(&cmd)
 
 	return &cmd
 }
 
 func newActionsExportAllCmd() *cobra.Command {
 	var outputDirectory string
+	var allViews bool
+	var secrets actionSecretsFlags
 
 	cmd := cobra.Command{
-		Use:   "export-all <view>",
+		Use:   "export-all (<view> | --all-views)",
 		Short: "Export all actions",
-		Long:  `Export all actions to yaml files with naming <sanitized-action-name>.yaml. All non-alphanumeric characters will be replaced with underscore.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Export all actions to yaml files with naming <sanitized-action-name>.yaml. All non-alphanumeric characters will be replaced with underscore.
+
+With --all-views, the actions of each repository and view are written to a subdirectory named <sanitized-view-name>.
+
+Secret fields are replaced with references as described for the export command.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			searchDomains := searchDomainsFromArgs(cmd, client, args, allViews)
 
-			var actions []api.Action
-			actions, err := client.Actions().List(view)
-			exitOnError(cmd, err, "Error fetching actions")
+			results := client.Actions().ListAll(context.Background(), searchDomains, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching actions")
+			}
 
-			for i := range actions {
-				yamlData, err := yaml.Marshal(&actions[i])
-				exitOnError(cmd, err, "Failed to serialize the action")
-				actionFilename := sanitizeTriggerName(actions[i].Name) + ".yaml"
+			for _, result := range results {
+				var actions []api.Action = result.Value
+				for i := range actions {
+					actionFilename := sanitizeTriggerName(actions[i].Name) + ".yaml"
+					outFilePath := exportFilePath(cmd, outputDirectory, result.Item, allViews, actionFilename)
 
-				var outFilePath string
-				if outputDirectory != "" {
-					outFilePath = outputDirectory + "/" + actionFilename
-				} else {
-					outFilePath = actionFilename
-				}
+					err := secrets.replaceSecrets(&actions[i], result.Item, outFilePath)
+					exitOnError(cmd, err, "Error exporting the action secrets")
+
+					yamlData, err := yaml.Marshal(&actions[i])
+					exitOnError(cmd, err, "Failed to serialize the action")
 
-				err = os.WriteFile(outFilePath, yamlData, 0600)
-				exitOnError(cmd, err, "Error saving the action to file")
+					err = os.WriteFile(outFilePath, yamlData, 0600)
+					exitOnError(cmd, err, "Error saving the action to file")
+				}
 			}
+
+			exitOnBulkErrors(cmd, results, "Error fetching actions")
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputDirectory, "outputDirectory", "d", "", "The file path where the actions should be written. Defaults to current directory.")
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "Export actions from all repositories and views.")
+	secrets.register(&cmd)
 
 	return &cmd
 }
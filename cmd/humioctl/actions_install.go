@@ -19,6 +19,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/humio/cli/internal/api"
 	"github.com/spf13/cobra"
@@ -27,6 +29,7 @@ import (
 
 func newActionsInstallCmd() *cobra.Command {
 	var filePath, url, name string
+	var checkSecrets bool
 
 	cmd := cobra.Command{
 		Use:   "install [flags] <repo-or-view>",
@@ -43,15 +46,25 @@ The install command allows you to install actions from a URL or from a local fil
 
 By default 'install' will not override existing actions with the same name.
 Use the --force flag to update existing actions with conflicting names.
+
+Secret fields may refer to an environment variable as ${ENV_VAR}, or to the contents of a file as ${file:path},
+as written by the export command. Relative paths are resolved from the directory of the action file, or from
+the working directory when using --url. The references are resolved before the action is installed.
+Use --check-secrets to only check that the action file contains no literal secrets, without installing it.
 `,
-		Args: cobra.ExactArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if checkSecrets {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			var content []byte
 			var err error
 
 			// Check that we got the right number of argument
 			// if we only got <view> you must supply --file or --url.
-			if l := len(args); l == 1 {
+			if l := len(args); l == 1 || checkSecrets {
 				if filePath != "" {
 					content, err = getActionFromFile(filePath)
 				} else if url != "" {
@@ -63,13 +76,24 @@ Use the --force flag to update existing actions with conflicting names.
 			}
 			exitOnError(cmd, err, "Failed to load the action")
 
-			client := NewApiClient(cmd)
-			viewName := args[0]
-
 			action := api.Action{}
 			err = yaml.Unmarshal(content, &action)
 			exitOnError(cmd, err, "The action's format was invalid")
 
+			if checkSecrets {
+				if literal := action.LiteralSecrets(); len(literal) > 0 {
+					exitOnError(cmd, api.ValidationError{Message: "literal secrets in " + strings.Join(literal, ", ")}, fmt.Sprintf("Action %q contains secrets", action.Name))
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Action %q contains no literal secrets\n", action.Name)
+				return
+			}
+
+			err = action.ResolveSecrets(filepath.Dir(filePath))
+			exitOnError(cmd, err, "Error resolving secrets")
+
+			client := NewApiClient(cmd)
+			viewName := args[0]
+
 			if name != "" {
 				action.Name = name
 			}
@@ -84,6 +108,7 @@ Use the --force flag to update existing actions with conflicting names.
 	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the action to install.")
 	cmd.Flags().StringVar(&url, "url", "", "A URL to fetch the action file from.")
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Install the action under a specific name, ignoring the `name` attribute in the action file.")
+	cmd.Flags().BoolVar(&checkSecrets, "check-secrets", false, "Only check that the action file contains no literal secrets, and fail if it does.")
 
 	return &cmd
 }
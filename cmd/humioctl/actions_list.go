@@ -15,31 +15,51 @@
 package main
 
 import (
+	"context"
+
 	"github.com/humio/cli/internal/format"
 	"github.com/spf13/cobra"
 )
 
 func newActionsListCmd() *cobra.Command {
+	var allViews bool
+
 	cmd := cobra.Command{
-		Use:   "list <repo-or-view>",
+		Use:   "list [flags] (<repo-or-view> | --all-views)",
 		Short: "List all actions in a repository or view.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			repoOrViewName := args[0]
 			client := NewApiClient(cmd)
+			searchDomains := searchDomainsFromArgs(cmd, client, args, allViews)
 
-			actions, err := client.Actions().List(repoOrViewName)
-			exitOnError(cmd, err, "Error fetching actions")
+			results := client.Actions().ListAll(context.Background(), searchDomains, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching actions")
+			}
 
 			var rows [][]format.Value
-			for i := 0; i < len(actions); i++ {
-				action := actions[i]
-				rows = append(rows, []format.Value{format.String(action.Name), format.String(action.Type)})
+			for _, result := range results {
+				for i := 0; i < len(result.Value); i++ {
+					action := result.Value[i]
+					row := []format.Value{format.String(action.Name), format.String(action.Type)}
+					if allViews {
+						row = append([]format.Value{format.String(result.Item)}, row...)
+					}
+					rows = append(rows, row)
+				}
 			}
 
-			printOverviewTable(cmd, []string{"Name", "Type"}, rows)
+			header := []string{"Name", "Type"}
+			if allViews {
+				header = append([]string{"View"}, header...)
+			}
+			printOverviewTable(cmd, header, rows)
+
+			exitOnBulkErrors(cmd, results, "Error fetching actions")
 		},
 	}
 
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "List actions in all repositories and views.")
+
 	return &cmd
 }
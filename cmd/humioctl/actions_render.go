@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/humio/cli/internal/actiontemplate"
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newActionsRenderCmd() *cobra.Command {
+	var filePath, eventPath string
+	var context actiontemplate.Context
+
+	cmd := cobra.Command{
+		Use:   "render --file <action.yaml> [flags]",
+		Short: "Render the message templates of an action file locally.",
+		Long: fmt.Sprintf(`Expand the placeholders in the templates of an action file, and show the request that would be sent.
+
+Actions sending HTTP requests are shown as the request with its JSON body. For Slack, OpsGenie, PagerDuty
+and VictorOps actions, the body is built from the message fields of the action and the alert.
+
+Events are read from a JSON file with --event, holding either a single event or a list of events.
+{field:<name>} and {field_raw:<name>} are replaced with the value of the field in the first event.
+The other supported placeholders are:
+
+  {%s}
+
+Unknown placeholders are reported, and make the command fail. E.g.
+
+  $ humioctl actions render --file ./action.yaml --event ./sample.json --alert-name "Too many errors"
+`, strings.Join(actiontemplate.Variables(), "}, {")),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if filePath == "" {
+				exitOnError(cmd, fmt.Errorf("--file is required"), "Failed to load the action")
+			}
+			content, err := getActionFromFile(filePath)
+			exitOnError(cmd, err, "Failed to load the action")
+
+			var action api.Action
+			err = yaml.Unmarshal(content, &action)
+			exitOnError(cmd, err, "The action's format was invalid")
+
+			if eventPath != "" {
+				content, err := getBytesFromFile(eventPath)
+				exitOnError(cmd, err, "Failed to load the events")
+				context.Events, err = parseEvents(content)
+				exitOnError(cmd, err, "The events' format was invalid")
+			}
+
+			now := time.Now()
+			context.TriggeredAt = now
+			context.QueryStart = now.Add(-24 * time.Hour)
+			context.QueryEnd = now
+			context.ActionName = action.Name
+
+			unknown := renderAction(cmd.OutOrStdout(), action, context)
+			if len(unknown) > 0 {
+				exitOnError(cmd, api.ValidationError{Message: "unknown placeholders " + strings.Join(unknown, ", ")}, "Invalid action templates")
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the action to render.")
+	cmd.Flags().StringVar(&eventPath, "event", "", "A JSON file with the event, or list of events, triggering the action.")
+	cmd.Flags().StringVar(&context.AlertName, "alert-name", "Example alert", "The value of {alert_name}.")
+	cmd.Flags().StringVar(&context.AlertDescription, "alert-description", "", "The value of {alert_description}.")
+	cmd.Flags().StringVar(&context.QueryString, "query-string", "", "The value of {query_string}.")
+	cmd.Flags().StringVar(&context.RepoName, "repo", "", "The value of {repo_name}.")
+
+	return &cmd
+}
+
+func parseEvents(content []byte) ([]map[string]interface{}, error) {
+	var events []map[string]interface{}
+	if err := json.Unmarshal(content, &events); err == nil {
+		return events, nil
+	}
+
+	var event map[string]interface{}
+	if err := json.Unmarshal(content, &event); err != nil {
+		return nil, err
+	}
+	return []map[string]interface{}{event}, nil
+}
+
+// renderAction writes the rendered templates of the action, and returns the unknown placeholders found.
+// For actions sending HTTP requests, the request is written with its JSON body.
+func renderAction(w io.Writer, action api.Action, context actiontemplate.Context) []string {
+	var unknown []string
+	render := func(template string) string {
+		result, u := actiontemplate.Render(template, context)
+		unknown = append(unknown, u...)
+		return result
+	}
+
+	switch {
+	case action.WebhookAction.Url != "":
+		context.ActionType = "WebhookAction"
+		headers := make([]api.HttpHeader, len(action.WebhookAction.Headers))
+		for i, header := range action.WebhookAction.Headers {
+			headers[i] = api.HttpHeader{Header: header.Header, Value: render(header.Value)}
+		}
+		// Only the body is JSON, so field values are escaped there and nowhere else.
+		bodyContext := context
+		bodyContext.EscapeJSON = true
+		body, u := actiontemplate.Render(action.WebhookAction.BodyTemplate, bodyContext)
+		unknown = append(unknown, u...)
+		actionRequest{
+			method:  action.WebhookAction.Method,
+			url:     render(action.WebhookAction.Url),
+			headers: headers,
+			body:    body,
+		}.write(w)
+	case len(action.EmailAction.Recipients) > 0:
+		context.ActionType = "EmailAction"
+		fmt.Fprintf(w, "To: %s\n", strings.Join(action.EmailAction.Recipients, ", "))
+		if action.EmailAction.SubjectTemplate != nil {
+			fmt.Fprintf(w, "Subject: %s\n", render(*action.EmailAction.SubjectTemplate))
+		}
+		if action.EmailAction.BodyTemplate != nil {
+			fmt.Fprintf(w, "\n%s\n", render(*action.EmailAction.BodyTemplate))
+		}
+	case action.SlackAction.Url != "":
+		context.ActionType = "SlackAction"
+		jsonRequest(action.SlackAction.Url, nil, map[string]interface{}{
+			"attachments": slackAttachments(action.SlackAction.Fields, render),
+		}).write(w)
+	case len(action.SlackPostMessageAction.Channels) > 0:
+		context.ActionType = "SlackPostMessageAction"
+		attachments := slackAttachments(action.SlackPostMessageAction.Fields, render)
+		authorization := []api.HttpHeader{{Header: "Authorization", Value: "Bearer " + action.SlackPostMessageAction.ApiToken}}
+		for i, channel := range action.SlackPostMessageAction.Channels {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			jsonRequest("https://slack.com/api/chat.postMessage", authorization, map[string]interface{}{
+				"channel":     channel,
+				"attachments": attachments,
+			}).write(w)
+		}
+	case action.OpsGenieAction.ApiUrl != "":
+		context.ActionType = "OpsGenieAction"
+		authorization := []api.HttpHeader{{Header: "Authorization", Value: "GenieKey " + action.OpsGenieAction.GenieKey}}
+		jsonRequest(strings.TrimSuffix(action.OpsGenieAction.ApiUrl, "/")+"/v2/alerts", authorization, map[string]interface{}{
+			"message":     render("{alert_name}"),
+			"description": render("{alert_description}"),
+			"details":     map[string]string{"events": render("{events_str}"), "url": render("{url}")},
+		}).write(w)
+	case action.PagerDutyAction.RoutingKey != "":
+		context.ActionType = "PagerDutyAction"
+		jsonRequest("https://events.pagerduty.com/v2/enqueue", nil, map[string]interface{}{
+			"routing_key":  action.PagerDutyAction.RoutingKey,
+			"event_action": "trigger",
+			"payload": map[string]interface{}{
+				"summary":        render("{alert_name}"),
+				"severity":       strings.ToLower(action.PagerDutyAction.Severity),
+				"source":         "LogScale",
+				"custom_details": map[string]string{"description": render("{alert_description}"), "events": render("{events_str}"), "url": render("{url}")},
+			},
+		}).write(w)
+	case action.VictorOpsAction.NotifyUrl != "":
+		context.ActionType = "VictorOpsAction"
+		jsonRequest(action.VictorOpsAction.NotifyUrl, nil, map[string]interface{}{
+			"message_type":        action.VictorOpsAction.MessageType,
+			"entity_display_name": render("{alert_name}"),
+			"state_message":       render("{alert_description}\n{events_str}"),
+		}).write(w)
+	case action.UploadFileAction.FileName != "":
+		context.ActionType = "UploadFileAction"
+		fmt.Fprintf(w, "File: %s\n", render(action.UploadFileAction.FileName))
+	default:
+		fmt.Fprintln(w, "The action has no message templates")
+	}
+
+	return unknown
+}
+
+// actionRequest is an HTTP request sent by an action.
+type actionRequest struct {
+	method  string
+	url     string
+	headers []api.HttpHeader
+	body    string
+}
+
+func jsonRequest(url string, headers []api.HttpHeader, body interface{}) actionRequest {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(body)
+
+	return actionRequest{
+		method:  http.MethodPost,
+		url:     url,
+		headers: append([]api.HttpHeader{{Header: "Content-Type", Value: "application/json"}}, headers...),
+		body:    strings.TrimSuffix(buf.String(), "\n"),
+	}
+}
+
+func (r actionRequest) write(w io.Writer) {
+	fmt.Fprintf(w, "%s %s\n", r.method, r.url)
+	for _, header := range r.headers {
+		fmt.Fprintf(w, "%s: %s\n", header.Header, header.Value)
+	}
+	fmt.Fprintf(w, "\n%s\n", r.body)
+}
+
+// slackAttachments returns the message fields of a Slack action as the fields of a Slack message attachment.
+func slackAttachments(fields []api.SlackField, render func(string) string) []map[string]interface{} {
+	attachmentFields := make([]map[string]interface{}, len(fields))
+	for i, field := range fields {
+		attachmentFields[i] = map[string]interface{}{"title": field.FieldName, "value": render(field.Value), "short": false}
+	}
+	return []map[string]interface{}{{"fields": attachmentFields}}
+}
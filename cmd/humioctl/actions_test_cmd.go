@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newActionsTestCmd() *cobra.Command {
+	var filePath, triggerName, eventData string
+
+	cmd := cobra.Command{
+		Use:   "test [flags] <repo-or-view> [<action>]",
+		Short: "Fire an action with a test event and report whether it succeeded.",
+		Long: `Fire an action with a test event, and report whether it succeeded or the error returned by the receiving end.
+
+Either test an existing action by name, or an action file before it is installed, e.g.
+
+  $ humioctl actions test viewName actionName
+
+  $ humioctl actions test viewName --file=./action.yaml
+
+By default a single synthetic event is used. Use --event-data to pass a JSON list of events instead.
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if filePath != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			viewName := args[0]
+			client := NewApiClient(cmd)
+
+			var action *api.Action
+			if filePath != "" {
+				content, err := getActionFromFile(filePath)
+				exitOnError(cmd, err, "Failed to load the action")
+
+				action = &api.Action{}
+				err = yaml.Unmarshal(content, action)
+				exitOnError(cmd, err, "The action's format was invalid")
+
+				err = action.ResolveSecrets(filepath.Dir(filePath))
+				exitOnError(cmd, err, "Error resolving secrets")
+			} else {
+				var err error
+				action, err = client.Actions().Get(viewName, args[1])
+				exitOnError(cmd, err, "Error fetching action")
+			}
+
+			if eventData == "" {
+				eventData = syntheticEventData(viewName, time.Now())
+			}
+
+			result, err := client.Actions().Test(viewName, action, triggerName, eventData)
+			exitOnError(cmd, err, "Error testing action")
+
+			if !result.Success {
+				exitOnError(cmd, fmt.Errorf("%s", result.Message), fmt.Sprintf("Action %q failed", action.Name))
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully tested action %q: %s\n", action.Name, result.Message)
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "Test the action in this file instead of an existing action.")
+	cmd.Flags().StringVar(&triggerName, "trigger-name", "humioctl test", "The name of the alert or scheduled search reported as triggering the action.")
+	cmd.Flags().StringVar(&eventData, "event-data", "", "A JSON list of events to fire the action with.")
+
+	return &cmd
+}
+
+// syntheticEventData returns a JSON list with a single event, shaped like the events produced by searching a repository.
+func syntheticEventData(viewName string, now time.Time) string {
+	events := []map[string]interface{}{{
+		"@timestamp": now.UnixMilli(),
+		"@rawstring": "Test event sent by humioctl actions test",
+		"#repo":      viewName,
+		"message":    "Test event sent by humioctl actions test",
+	}}
+
+	data, _ := json.Marshal(events)
+	return string(data)
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/humio/cli/internal/api"
@@ -68,39 +69,43 @@ func newAggregateAlertsExportCmd() *cobra.Command {
 
 func newAggregateAlertsExportAllCmd() *cobra.Command {
 	var outputDirectory string
+	var allViews bool
 
 	cmd := cobra.Command{
-		Use:   "export-all <view>",
+		Use:   "export-all (<view> | --all-views)",
 		Short: "Export all aggregate alerts",
-		Long:  `Export all aggregate alerts to yaml files with naming <sanitized-aggregate-alert-name>.yaml. All non-alphanumeric characters will be replaced with underscore.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Export all aggregate alerts to yaml files with naming <sanitized-aggregate-alert-name>.yaml. All non-alphanumeric characters will be replaced with underscore.
+
+With --all-views, the aggregate alerts of each repository and view are written to a subdirectory named <sanitized-view-name>.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			searchDomains := searchDomainsFromArgs(cmd, client, args, allViews)
 
-			var aggregateAlerts []api.AggregateAlert
-			aggregateAlerts, err := client.AggregateAlerts().List(view)
-			exitOnError(cmd, err, "Error fetching aggregate alerts")
-
-			for i := range aggregateAlerts {
-				yamlData, err := yaml.Marshal(&aggregateAlerts[i])
-				exitOnError(cmd, err, "Failed to serialize the aggregate alert")
-				alertFilename := sanitizeTriggerName(aggregateAlerts[i].Name) + ".yaml"
-
-				var outFilePath string
-				if outputDirectory != "" {
-					outFilePath = outputDirectory + "/" + alertFilename
-				} else {
-					outFilePath = alertFilename
-				}
+			results := client.AggregateAlerts().ListAll(context.Background(), searchDomains, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching aggregate alerts")
+			}
+
+			for _, result := range results {
+				aggregateAlerts := result.Value
+				for i := range aggregateAlerts {
+					yamlData, err := yaml.Marshal(&aggregateAlerts[i])
+					exitOnError(cmd, err, "Failed to serialize the aggregate alert")
+					alertFilename := sanitizeTriggerName(aggregateAlerts[i].Name) + ".yaml"
 
-				err = os.WriteFile(outFilePath, yamlData, 0600)
-				exitOnError(cmd, err, "Error saving the aggregate alert to file")
+					outFilePath := exportFilePath(cmd, outputDirectory, result.Item, allViews, alertFilename)
+					err = os.WriteFile(outFilePath, yamlData, 0600)
+					exitOnError(cmd, err, "Error saving the aggregate alert to file")
+				}
 			}
+
+			exitOnBulkErrors(cmd, results, "Error fetching aggregate alerts")
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputDirectory, "outputDirectory", "d", "", "The file path where the aggregate alerts should be written. Defaults to current directory.")
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "Export aggregate alerts from all repositories and views.")
 
 	return &cmd
 }
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"strings"
 
 	"github.com/humio/cli/internal/format"
@@ -22,40 +23,57 @@ import (
 )
 
 func newAggregateAlertsListCmd() *cobra.Command {
+	var allViews bool
+
 	cmd := cobra.Command{
-		Use:   "list <view>",
+		Use:   "list [flags] (<view> | --all-views)",
 		Short: "List all aggregate alerts in a view.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			views := searchDomainsFromArgs(cmd, client, args, allViews)
+
+			results := client.AggregateAlerts().ListAll(context.Background(), views, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching aggregate alerts")
+			}
 
-			aggregateAlerts, err := client.AggregateAlerts().List(view)
-			exitOnError(cmd, err, "Error fetching aggregate alerts")
-
-			var rows = make([][]format.Value, len(aggregateAlerts))
-			for i := range aggregateAlerts {
-				aggregateAlert := aggregateAlerts[i]
-				rows[i] = []format.Value{
-					format.String(aggregateAlert.ID),
-					format.String(aggregateAlert.Name),
-					format.StringPtr(aggregateAlert.Description),
-					format.String(strings.Join(aggregateAlert.ActionNames, ", ")),
-					format.String(strings.Join(aggregateAlert.Labels, ", ")),
-					format.Bool(aggregateAlert.Enabled),
-					format.StringPtr(aggregateAlert.ThrottleField),
-					format.Int(aggregateAlert.ThrottleTimeSeconds),
-					format.Int(aggregateAlert.SearchIntervalSeconds),
-					format.String(aggregateAlert.QueryTimestampType),
-					format.String(aggregateAlert.TriggerMode),
-					format.String(aggregateAlert.OwnershipRunAsID),
-					format.String(aggregateAlert.QueryOwnershipType),
+			var rows [][]format.Value
+			for _, result := range results {
+				for _, aggregateAlert := range result.Value {
+					row := []format.Value{
+						format.String(aggregateAlert.ID),
+						format.String(aggregateAlert.Name),
+						format.StringPtr(aggregateAlert.Description),
+						format.String(strings.Join(aggregateAlert.ActionNames, ", ")),
+						format.String(strings.Join(aggregateAlert.Labels, ", ")),
+						format.Bool(aggregateAlert.Enabled),
+						format.StringPtr(aggregateAlert.ThrottleField),
+						format.Int(aggregateAlert.ThrottleTimeSeconds),
+						format.Int(aggregateAlert.SearchIntervalSeconds),
+						format.String(aggregateAlert.QueryTimestampType),
+						format.String(aggregateAlert.TriggerMode),
+						format.String(aggregateAlert.OwnershipRunAsID),
+						format.String(aggregateAlert.QueryOwnershipType),
+					}
+					if allViews {
+						row = append([]format.Value{format.String(result.Item)}, row...)
+					}
+					rows = append(rows, row)
 				}
 			}
 
-			printOverviewTable(cmd, []string{"ID", "Name", "Description", "Action Names", "Labels", "Enabled", "Throttle Field", "Throttle Time Seconds", "Search Interval Seconds", "Query Timestamp Type", "Trigger Mode", "Run As UserID", "Query Ownership Type"}, rows)
+			header := []string{"ID", "Name", "Description", "Action Names", "Labels", "Enabled", "Throttle Field", "Throttle Time Seconds", "Search Interval Seconds", "Query Timestamp Type", "Trigger Mode", "Run As UserID", "Query Ownership Type"}
+			if allViews {
+				header = append([]string{"View"}, header...)
+			}
+			printOverviewTable(cmd, header, rows)
+
+			exitOnBulkErrors(cmd, results, "Error fetching aggregate alerts")
 		},
 	}
 
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "List aggregate alerts in all repositories and views.")
+
 	return &cmd
 }
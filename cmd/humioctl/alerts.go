@@ -30,6 +30,13 @@ func newAlertsCmd() *cobra.Command {
 	cmd.AddCommand(newAlertsExportAllCmd())
 	cmd.AddCommand(newAlertsRemoveCmd())
 	cmd.AddCommand(newAlertsShowCmd())
+	cmd.AddCommand(newAlertsEnableCmd())
+	cmd.AddCommand(newAlertsDisableCmd())
+	cmd.AddCommand(newAlertsLabelsCmd())
+	cmd.AddCommand(newAlertsMaintenanceCmd())
+	cmd.AddCommand(newAlertsHealthCmd())
+	cmd.AddCommand(newAlertsMigrateCmd())
+	cmd.AddCommand(newAlertsBacktestCmd())
 
 	return cmd
 }
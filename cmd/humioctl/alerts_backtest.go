@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// backtestAlert holds the fields of an alert, filter alert or aggregate alert file used for backtesting.
+// Schedule is only read to reject scheduled searches.
+type backtestAlert struct {
+	Name                  string
+	Schedule              string
+	QueryString           string  `yaml:"queryString"`
+	QueryStart            string  `yaml:"queryStart"`
+	SearchIntervalSeconds int64   `yaml:"searchIntervalSeconds"`
+	ThrottleTimeMillis    int64   `yaml:"throttleTimeMillis"`
+	ThrottleTimeSeconds   int64   `yaml:"throttleTimeSeconds"`
+	ThrottleField         *string `yaml:"throttleField"`
+}
+
+func newAlertsBacktestCmd() *cobra.Command {
+	var filePath, over, interval string
+	var maxWindows int
+
+	cmd := cobra.Command{
+		Use:   "backtest [flags] <view> --file <alert.yaml> [--over <period>]",
+		Short: "Estimate how often an alert would have fired in the past.",
+		Long: `Estimate how often an alert would have fired in the past.
+
+The query of the alert is run over consecutive windows covering the period given by --over,
+e.g. 7d. Each window is as long as the search interval of the alert, which is taken from
+searchIntervalSeconds or queryStart in the file, or from --interval.
+
+The report shows how many windows had results, and how many times the alert would have
+triggered when honouring throttleTimeSeconds (or throttleTimeMillis) and throttleField.
+As the alert is assumed to run once per window, the result is an estimate.
+
+Scheduled searches cannot be backtested, as they run on a cron schedule over a search
+interval given by both queryStart and queryEnd.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			client := NewApiClient(cmd)
+
+			content, err := getBytesFromFile(filePath)
+			exitOnError(cmd, err, "Failed to load the alert")
+
+			var alert backtestAlert
+			err = yaml.Unmarshal(content, &alert)
+			exitOnError(cmd, err, "The alert is invalid")
+			if alert.QueryString == "" {
+				exitOnError(cmd, api.ValidationError{Message: "the alert has no queryString"}, "The alert is invalid")
+			}
+			if alert.Schedule != "" {
+				exitOnError(cmd, api.ValidationError{Message: "scheduled searches cannot be backtested"}, "The alert is invalid")
+			}
+
+			period, err := api.ParseRelativeTime(over)
+			exitOnError(cmd, err, "Invalid --over")
+
+			searchInterval, err := backtestSearchInterval(alert, interval)
+			exitOnError(cmd, err, "Unable to determine the search interval")
+
+			windows := api.BacktestWindows(time.Now().Truncate(time.Second), period, searchInterval)
+			if len(windows) > maxWindows {
+				exitOnError(cmd, fmt.Errorf("backtesting over %s in windows of %s needs %d queries, raise --max-windows to allow it", over, searchInterval, len(windows)), "Too many windows")
+			}
+
+			ctx := contextCancelledOnInterrupt(context.Background())
+			results := api.Bulk(ctx, windows, bulkOptions(cmd), func(ctx context.Context, window api.BacktestWindow) ([]map[string]interface{}, error) {
+				startMillis, endMillis := window.Start.UnixMilli(), window.End.UnixMilli()
+				return runQueryToCompletion(ctx, client, view, api.Query{
+					QueryString: alert.QueryString,
+					StartMillis: &startMillis,
+					EndMillis:   &endMillis,
+				})
+			})
+
+			windowsWithResults, events := 0, 0
+			for i, result := range results {
+				exitOnError(cmd, result.Err, fmt.Sprintf("Error running the query from %s", windows[i].Start.Format(time.RFC3339)))
+				windows[i].Events = result.Value
+				if len(result.Value) > 0 {
+					windowsWithResults++
+					events += len(result.Value)
+				}
+			}
+
+			throttle := time.Duration(alert.ThrottleTimeSeconds) * time.Second
+			if alert.ThrottleTimeMillis != 0 {
+				throttle = time.Duration(alert.ThrottleTimeMillis) * time.Millisecond
+			}
+
+			printDetailsTable(cmd, [][]format.Value{
+				{format.String("Name"), format.String(alert.Name)},
+				{format.String("Period"), format.String(period.String())},
+				{format.String("Search Interval"), format.String(searchInterval.String())},
+				{format.String("Windows"), format.Int(len(windows))},
+				{format.String("Windows With Results"), format.Int(windowsWithResults)},
+				{format.String("Results"), format.Int(events)},
+				{format.String("Throttle Time"), format.String(throttle.String())},
+				{format.String("Throttle Field"), format.StringPtr(alert.ThrottleField)},
+				{format.String("Triggers"), format.Int(api.CountTriggers(windows, throttle, alert.ThrottleField))},
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&filePath, "file", "f", "", "The alert, filter alert or aggregate alert file to backtest.")
+	cmd.Flags().StringVar(&over, "over", "7d", "The period to backtest over, ending now.")
+	cmd.Flags().StringVar(&interval, "interval", "", "The search interval, e.g. 15m. Overrides the interval of the alert, and is required for filter alerts.")
+	cmd.Flags().IntVar(&maxWindows, "max-windows", 1000, "The maximum number of windows, and therefore queries, to run.")
+	_ = cmd.MarkFlagRequired("file")
+
+	return &cmd
+}
+
+// backtestSearchInterval returns the search interval given by --interval, or else by the alert.
+func backtestSearchInterval(alert backtestAlert, interval string) (time.Duration, error) {
+	var searchInterval time.Duration
+	switch {
+	case interval != "":
+		d, err := api.ParseRelativeTime(interval)
+		if err != nil {
+			return 0, err
+		}
+		searchInterval = d
+	case alert.SearchIntervalSeconds != 0:
+		searchInterval = time.Duration(alert.SearchIntervalSeconds) * time.Second
+	case alert.QueryStart != "":
+		d, err := api.ParseRelativeTime(alert.QueryStart)
+		if err != nil {
+			return 0, err
+		}
+		searchInterval = d
+	default:
+		return 0, fmt.Errorf("the alert has no search interval, use --interval")
+	}
+	if searchInterval <= 0 {
+		return 0, fmt.Errorf("the search interval must be positive")
+	}
+	return searchInterval, nil
+}
+
+// runQueryToCompletion runs a query job until it is done, and returns the resulting events.
+func runQueryToCompletion(ctx context.Context, client *api.Client, repository string, query api.Query) ([]map[string]interface{}, error) {
+	id, err := client.QueryJobs().Create(repository, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = client.QueryJobs().Delete(repository, id)
+	}()
+
+	poller := queryJobPoller{
+		queryJobs:  client.QueryJobs(),
+		repository: repository,
+		id:         id,
+	}
+	for {
+		result, err := poller.WaitAndPollContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if result.Done {
+			return result.Events, nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newAlertsEnableCmd() *cobra.Command {
+	return newAlertsSetEnabledCmd("enable", true)
+}
+
+func newAlertsDisableCmd() *cobra.Command {
+	return newAlertsSetEnabledCmd("disable", false)
+}
+
+func newAlertsSetEnabledCmd(use string, enabled bool) *cobra.Command {
+	var selection triggerSelection
+
+	cmd := cobra.Command{
+		Use:   use + " [flags] (<view> | --all-views) (--name <name> | --label <label>)",
+		Short: "Enable alerts and scheduled searches without reinstalling them.",
+		Long: `Enable alerts, filter alerts, aggregate alerts and scheduled searches without reinstalling them.
+
+Select a single one with --name, or all with a label using --label.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+			triggers := selection.selectTriggers(cmd, client, args)
+			_, err := setTriggersEnabled(context.Background(), cmd, client, triggers, enabled)
+			exitOnError(cmd, err, "Error changing alerts")
+		},
+	}
+	if !enabled {
+		cmd.Short = "Disable alerts and scheduled searches without reinstalling them."
+		cmd.Long = `Disable alerts, filter alerts, aggregate alerts and scheduled searches without reinstalling them.
+
+Select a single one with --name, or all with a label using --label.
+Use "alerts maintenance" to disable them for a limited time.`
+	}
+
+	selection.addFlags(&cmd, api.TriggerKinds)
+
+	return &cmd
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/humio/cli/internal/api"
@@ -57,39 +58,43 @@ func newAlertsExportCmd() *cobra.Command {
 
 func newAlertsExportAllCmd() *cobra.Command {
 	var outputDirectory string
+	var allViews bool
 
 	cmd := cobra.Command{
-		Use:   "export-all <view>",
+		Use:   "export-all (<view> | --all-views)",
 		Short: "Export all alerts",
-		Long:  `Export all alerts to yaml files with naming <sanitized-alert-name>.yaml. All non-alphanumeric characters will be replaced with underscore.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Export all alerts to yaml files with naming <sanitized-alert-name>.yaml. All non-alphanumeric characters will be replaced with underscore.
+
+With --all-views, the alerts of each repository and view are written to a subdirectory named <sanitized-view-name>.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			searchDomains := searchDomainsFromArgs(cmd, client, args, allViews)
 
-			var alerts []api.Alert
-			alerts, err := client.Alerts().List(view)
-			exitOnError(cmd, err, "Error fetching alerts")
+			results := client.Alerts().ListAll(context.Background(), searchDomains, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching alerts")
+			}
 
-			for i := range alerts {
-				yamlData, err := yaml.Marshal(&alerts[i])
-				exitOnError(cmd, err, "Failed to serialize the alert")
-				alertFilename := sanitizeTriggerName(alerts[i].Name) + ".yaml"
+			for _, result := range results {
+				var alerts []api.Alert = result.Value
+				for i := range alerts {
+					yamlData, err := yaml.Marshal(&alerts[i])
+					exitOnError(cmd, err, "Failed to serialize the alert")
+					alertFilename := sanitizeTriggerName(alerts[i].Name) + ".yaml"
 
-				var outFilePath string
-				if outputDirectory != "" {
-					outFilePath = outputDirectory + "/" + alertFilename
-				} else {
-					outFilePath = alertFilename
+					outFilePath := exportFilePath(cmd, outputDirectory, result.Item, allViews, alertFilename)
+					err = os.WriteFile(outFilePath, yamlData, 0600)
+					exitOnError(cmd, err, "Error saving the alert to file")
 				}
-
-				err = os.WriteFile(outFilePath, yamlData, 0600)
-				exitOnError(cmd, err, "Error saving the alert to file")
 			}
+
+			exitOnBulkErrors(cmd, results, "Error fetching alerts")
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputDirectory, "outputDirectory", "d", "", "The file path where the alerts should be written. Defaults to current directory.")
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "Export alerts from all repositories and views.")
 
 	return &cmd
 }
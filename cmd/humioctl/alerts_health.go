@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newAlertsHealthCmd() *cobra.Command {
+	var allViews, clearErrors bool
+	var kinds []string
+
+	cmd := cobra.Command{
+		Use:   "health [flags] (<view> | --all-views)",
+		Short: "List alerts and scheduled searches in an error state.",
+		Long: `List alerts, filter alerts, aggregate alerts and scheduled searches in an error state,
+with the last error and the time they last triggered.
+
+Use --clear to clear the errors once their cause has been fixed. Errors are set again
+the next time the alert runs if the cause persists.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			var failing []api.Trigger
+			for _, trigger := range listTriggers(cmd, client, args, allViews, kinds) {
+				if trigger.LastError != nil {
+					failing = append(failing, trigger)
+				}
+			}
+
+			rows := make([][]format.Value, len(failing))
+			for i, trigger := range failing {
+				rows[i] = []format.Value{
+					format.String(trigger.SearchDomain),
+					format.String(string(trigger.Kind)),
+					format.String(trigger.Name),
+					format.Bool(trigger.Enabled),
+					format.StringPtr(trigger.LastError),
+					formatMillis(trigger.LastTriggered),
+				}
+			}
+			printOverviewTable(cmd, []string{"View", "Type", "Name", "Enabled", "Last Error", "Last Triggered"}, rows)
+
+			if !clearErrors {
+				return
+			}
+			var failed bool
+			for _, trigger := range failing {
+				if err := client.Triggers().ClearError(trigger); err != nil {
+					cmd.PrintErrf("Error clearing error of %s %q in %q: %s\n", trigger.Kind, trigger.Name, trigger.SearchDomain, err)
+					failed = true
+					continue
+				}
+				cmd.PrintErrf("Successfully cleared error of %s %q in %q\n", trigger.Kind, trigger.Name, trigger.SearchDomain)
+			}
+			if failed {
+				exitOnError(cmd, fmt.Errorf("not all errors could be cleared"), "Error clearing errors")
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "Check alerts in all repositories and views.")
+	cmd.Flags().BoolVar(&clearErrors, "clear", false, "Clear the errors of the listed alerts and scheduled searches.")
+	addTriggerKindsFlag(&cmd, &kinds, api.TriggerKinds)
+
+	return &cmd
+}
+
+// formatMillis formats a time given in milliseconds since the epoch, leaving it empty if not set.
+func formatMillis(millis *int64) format.Value {
+	if millis == nil {
+		return format.String("")
+	}
+	return format.String(time.UnixMilli(*millis).UTC().Format(time.RFC3339))
+}
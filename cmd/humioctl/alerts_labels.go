@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newAlertsLabelsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "labels",
+		Short: "Add or remove labels of alerts and scheduled searches",
+	}
+
+	cmd.AddCommand(newAlertsLabelsChangeCmd("add", true))
+	cmd.AddCommand(newAlertsLabelsChangeCmd("remove", false))
+
+	return cmd
+}
+
+func newAlertsLabelsChangeCmd(use string, add bool) *cobra.Command {
+	var selection triggerSelection
+
+	short := "Add a label to alerts and scheduled searches."
+	if !add {
+		short = "Remove a label from alerts and scheduled searches."
+	}
+
+	cmd := cobra.Command{
+		Use:   use + " [flags] (<view> | --all-views) <label> (--name <name> | --label <label>)",
+		Short: short,
+		Long: short + `
+
+Only alerts and scheduled searches support changing labels individually.
+Filter alerts and aggregate alerts must be reinstalled with the changed labels.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if selection.allViews {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			label := args[len(args)-1]
+			client := NewApiClient(cmd)
+			triggers := selection.selectTriggers(cmd, client, args[:len(args)-1])
+
+			var failed bool
+			for _, trigger := range triggers {
+				if trigger.HasLabel(label) == add {
+					continue
+				}
+				var err error
+				if add {
+					err = client.Triggers().AddLabel(trigger, label)
+				} else {
+					err = client.Triggers().RemoveLabel(trigger, label)
+				}
+				if err != nil {
+					cmd.PrintErrf("Error changing labels of %s %q in %q: %s\n", trigger.Kind, trigger.Name, trigger.SearchDomain, err)
+					failed = true
+					continue
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Successfully changed labels of %s %q in %q\n", trigger.Kind, trigger.Name, trigger.SearchDomain)
+			}
+			if failed {
+				exitOnError(cmd, fmt.Errorf("not all labels could be changed"), "Error changing labels")
+			}
+		},
+	}
+
+	selection.addFlags(&cmd, []api.TriggerKind{api.TriggerKindAlert, api.TriggerKindScheduledSearch})
+
+	return &cmd
+}
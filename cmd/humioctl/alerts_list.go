@@ -15,49 +15,81 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
+	"github.com/humio/cli/internal/api"
 	"github.com/humio/cli/internal/format"
 	"github.com/spf13/cobra"
 )
 
 func newAlertsListCmd() *cobra.Command {
+	var allViews bool
+
 	cmd := cobra.Command{
-		Use:   "list [flags] <view>",
+		Use:   "list [flags] (<view> | --all-views)",
 		Short: "List all alerts in a view.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			views := searchDomainsFromArgs(cmd, client, args, allViews)
 
-			alerts, err := client.Alerts().List(view)
-			exitOnError(cmd, err, "Error fetching alerts")
+			results := api.Bulk(context.Background(), views, bulkOptions(cmd), func(_ context.Context, view string) ([][]format.Value, error) {
+				alerts, err := client.Alerts().List(view)
+				if err != nil {
+					return nil, err
+				}
 
-			actions, err := client.Actions().List(view)
-			exitOnError(cmd, err, "Unable to fetch notifier details")
+				actions, err := client.Actions().List(view)
+				if err != nil {
+					return nil, fmt.Errorf("unable to fetch notifier details: %w", err)
+				}
+
+				var notifierMap = map[string]string{}
+				for _, action := range actions {
+					notifierMap[action.ID] = action.Name
+				}
 
-			var notifierMap = map[string]string{}
-			for _, action := range actions {
-				notifierMap[action.ID] = action.Name
+				var rows [][]format.Value
+				for i := 0; i < len(alerts); i++ {
+					alert := alerts[i]
+					var notifierNames []string
+					for _, notifierID := range alert.Actions {
+						notifierNames = append(notifierNames, notifierMap[notifierID])
+					}
+					row := []format.Value{
+						format.String(alert.Name),
+						format.Bool(alert.Enabled),
+						format.StringPtr(alert.Description),
+						format.String(strings.Join(notifierNames, ", "))}
+					if allViews {
+						row = append([]format.Value{format.String(view)}, row...)
+					}
+					rows = append(rows, row)
+				}
+				return rows, nil
+			})
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching alerts")
 			}
 
 			var rows [][]format.Value
-			for i := 0; i < len(alerts); i++ {
-				alert := alerts[i]
-				var notifierNames []string
-				for _, notifierID := range alert.Actions {
-					notifierNames = append(notifierNames, notifierMap[notifierID])
-				}
-				rows = append(rows, []format.Value{
-					format.String(alert.Name),
-					format.Bool(alert.Enabled),
-					format.StringPtr(alert.Description),
-					format.String(strings.Join(notifierNames, ", "))})
+			for _, result := range results {
+				rows = append(rows, result.Value...)
 			}
 
-			printOverviewTable(cmd, []string{"Name", "Enabled", "Description", "Actions"}, rows)
+			header := []string{"Name", "Enabled", "Description", "Actions"}
+			if allViews {
+				header = append([]string{"View"}, header...)
+			}
+			printOverviewTable(cmd, header, rows)
+
+			exitOnBulkErrors(cmd, results, "Error fetching alerts")
 		},
 	}
 
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "List alerts in all repositories and views.")
+
 	return &cmd
 }
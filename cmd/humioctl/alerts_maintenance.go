@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newAlertsMaintenanceCmd() *cobra.Command {
+	var selection triggerSelection
+	var duration time.Duration
+
+	cmd := cobra.Command{
+		Use:   "maintenance [flags] (<view> | --all-views) (--name <name> | --label <label>) --for <duration>",
+		Short: "Disable alerts and scheduled searches for a limited time.",
+		Long: `Disable alerts and scheduled searches for a limited time, e.g. during planned maintenance.
+
+The selected alerts which are enabled are disabled, and the command waits until the
+time given by --for has passed before enabling them again. Alerts which were already
+disabled are left disabled. The command must keep running for the alerts to be enabled
+again; interrupting it with Ctrl-C, or terminating it or closing its terminal, ends the
+maintenance early. If some alerts cannot be disabled, the maintenance ends right away and
+the alerts which were disabled are enabled again.
+
+Alerts and scheduled searches disabled by the maintenance are labelled
+maintenance-until:<end-time-in-RFC3339> until they are enabled again. If the command is
+killed before it can enable them, they can be found and enabled with the label, e.g.
+
+  $ humioctl alerts enable --all-views --label maintenance-until:2024-01-01T02:00:00Z
+  $ humioctl alerts labels remove --all-views maintenance-until:2024-01-01T02:00:00Z --label maintenance-until:2024-01-01T02:00:00Z
+
+Filter alerts and aggregate alerts cannot be labelled this way.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if duration <= 0 {
+				exitOnError(cmd, fmt.Errorf("--for must be a positive duration such as 2h"), "Invalid arguments")
+			}
+
+			ctx := contextCancelledOnInterrupt(context.Background())
+			client := NewApiClient(cmd)
+			triggers := selection.selectTriggers(cmd, client, args)
+
+			var enabled []api.Trigger
+			for _, trigger := range triggers {
+				if trigger.Enabled {
+					enabled = append(enabled, trigger)
+				}
+			}
+
+			until := time.Now().Add(duration).UTC()
+			label := maintenanceLabel(until)
+
+			// Whatever happens while disabling or waiting, the alerts which were disabled are enabled again.
+			disabled, disableErr := setTriggersEnabled(ctx, cmd, client, enabled, false)
+			if errors.Is(disableErr, context.Canceled) {
+				disableErr = nil
+			}
+			labelled := addMaintenanceLabel(cmd, client, disabled, label)
+			if disableErr == nil && ctx.Err() == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "Maintenance until %s, press Ctrl-C to end it early\n", until.Format(time.RFC3339))
+				if len(labelled) > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "The disabled alerts are labelled %q until they are enabled again\n", label)
+				}
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Until(until)):
+				}
+			}
+
+			for i := range disabled {
+				disabled[i].Enabled = false
+			}
+			reenabled, enableErr := setTriggersEnabled(context.Background(), cmd, client, disabled, true)
+			removeMaintenanceLabel(cmd, client, labelled, reenabled, label)
+
+			exitOnError(cmd, errors.Join(disableErr, enableErr), "Error during maintenance")
+		},
+	}
+
+	selection.addFlags(&cmd, api.TriggerKinds)
+	cmd.Flags().DurationVar(&duration, "for", 0, "How long to disable the alerts for, e.g. 2h.")
+	_ = cmd.MarkFlagRequired("for")
+
+	return &cmd
+}
+
+// maintenanceLabel returns the label of alerts disabled by a maintenance ending at until.
+func maintenanceLabel(until time.Time) string {
+	return "maintenance-until:" + until.Format(time.RFC3339)
+}
+
+// addMaintenanceLabel adds label to the triggers supporting it, so they can be found if the maintenance does not
+// enable them again, and returns the triggers which were labelled.
+func addMaintenanceLabel(cmd *cobra.Command, client *api.Client, triggers []api.Trigger, label string) []api.Trigger {
+	var labelled []api.Trigger
+	for _, trigger := range triggers {
+		if !trigger.LabelsChangeable() {
+			continue
+		}
+		if err := client.Triggers().AddLabel(trigger, label); err != nil {
+			cmd.PrintErrf("Warning: could not label %s %q in %q with %q: %s\n", trigger.Kind, trigger.Name, trigger.SearchDomain, label, err)
+			continue
+		}
+		labelled = append(labelled, trigger)
+	}
+	return labelled
+}
+
+// removeMaintenanceLabel removes label from the labelled triggers which were enabled again.
+// Triggers which are still disabled keep the label.
+func removeMaintenanceLabel(cmd *cobra.Command, client *api.Client, labelled, enabled []api.Trigger, label string) {
+	for _, trigger := range labelled {
+		if !containsTrigger(enabled, trigger) {
+			continue
+		}
+		if err := client.Triggers().RemoveLabel(trigger, label); err != nil {
+			cmd.PrintErrf("Warning: could not remove label %q from %s %q in %q: %s\n", label, trigger.Kind, trigger.Name, trigger.SearchDomain, err)
+		}
+	}
+}
+
+func containsTrigger(triggers []api.Trigger, trigger api.Trigger) bool {
+	for _, t := range triggers {
+		if t.Kind == trigger.Kind && t.SearchDomain == trigger.SearchDomain && t.ID == trigger.ID {
+			return true
+		}
+	}
+	return false
+}
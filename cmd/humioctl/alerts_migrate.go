@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newAlertsMigrateCmd() *cobra.Command {
+	var disableOld, dryRun bool
+
+	cmd := cobra.Command{
+		Use:   "migrate [flags] <view> [<alert>]",
+		Short: "Migrate legacy alerts to filter alerts or aggregate alerts.",
+		Long: `Migrate legacy alerts to filter alerts or aggregate alerts.
+
+LogScale analyzes the query of each legacy alert to decide whether it becomes a filter
+alert or an aggregate alert. Aggregate alerts use the query start of the legacy alert as
+search interval. Throttling, actions, labels and query ownership are carried over.
+
+The new alerts are created disabled, with the same names as the legacy alerts. Legacy
+alerts with a filter alert or aggregate alert of the same name are skipped, so the
+command can be run again after fixing failed migrations. Use --disable-old to disable
+the legacy alerts which were migrated.`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			client := NewApiClient(cmd)
+
+			var alerts []api.Alert
+			if len(args) == 2 {
+				alert, err := client.Alerts().Get(view, args[1])
+				exitOnError(cmd, err, "Error fetching alert")
+				alerts = []api.Alert{*alert}
+			} else {
+				var err error
+				alerts, err = client.Alerts().List(view)
+				exitOnError(cmd, err, "Error fetching alerts")
+			}
+
+			existing, err := client.Triggers().List(view, []api.TriggerKind{api.TriggerKindFilterAlert, api.TriggerKindAggregateAlert})
+			exitOnError(cmd, err, "Error fetching filter alerts and aggregate alerts")
+			existingNames := map[string]api.TriggerKind{}
+			for _, trigger := range existing {
+				existingNames[trigger.Name] = trigger.Kind
+			}
+
+			var rows [][]format.Value
+			var failed bool
+			for _, alert := range alerts {
+				kind, status, notes := migrateAlert(client, view, alert, existingNames, disableOld, dryRun)
+				if status == "failed" {
+					failed = true
+				}
+				rows = append(rows, []format.Value{
+					format.String(alert.Name),
+					format.String(string(kind)),
+					format.String(status),
+					format.String(strings.Join(notes, "; ")),
+				})
+			}
+
+			printOverviewTable(cmd, []string{"Alert", "Migrated To", "Status", "Notes"}, rows)
+
+			if failed {
+				exitOnError(cmd, fmt.Errorf("not all alerts could be migrated"), "Error migrating alerts")
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&disableOld, "disable-old", false, "Disable the legacy alerts which were migrated.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report how the alerts would be migrated.")
+
+	return &cmd
+}
+
+// migrateAlert migrates a single legacy alert, and returns the kind of alert it became, the status of the migration and notes about it.
+func migrateAlert(client *api.Client, view string, alert api.Alert, existingNames map[string]api.TriggerKind, disableOld, dryRun bool) (api.TriggerKind, string, []string) {
+	if kind, ok := existingNames[alert.Name]; ok {
+		return kind, "skipped", []string{fmt.Sprintf("a %s with the same name already exists", kind)}
+	}
+
+	kind, err := client.Alerts().SuggestedKind(view, alert.QueryString)
+	if err != nil {
+		return "", "failed", []string{err.Error()}
+	}
+	if kind == "" {
+		return "", "skipped", []string{"the query can only be used by a legacy alert"}
+	}
+
+	migration, err := api.PlanAlertMigration(alert, kind)
+	if err != nil {
+		return kind, "failed", []string{err.Error()}
+	}
+	if dryRun {
+		return kind, "would migrate", migration.Notes
+	}
+
+	switch kind {
+	case api.TriggerKindFilterAlert:
+		_, err = client.FilterAlerts().Create(view, migration.FilterAlert)
+	case api.TriggerKindAggregateAlert:
+		_, err = client.AggregateAlerts().Create(view, migration.AggregateAlert)
+	}
+	if err != nil {
+		return kind, "failed", append(migration.Notes, err.Error())
+	}
+
+	if disableOld && alert.Enabled {
+		legacy := api.Trigger{Kind: api.TriggerKindAlert, ID: alert.ID, Name: alert.Name, SearchDomain: view}
+		if err := client.Triggers().SetEnabled(legacy, false); err != nil {
+			return kind, "failed", append(migration.Notes, fmt.Sprintf("unable to disable the legacy alert: %s", err))
+		}
+		migration.Notes = append(migration.Notes, "legacy alert disabled")
+	}
+
+	return kind, "migrated", migration.Notes
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// triggerSelection holds the flags selecting alerts and scheduled searches for commands operating on many of them at once.
+type triggerSelection struct {
+	allViews bool
+	name     string
+	labels   []string
+	kinds    []string
+}
+
+func (s *triggerSelection) addFlags(cmd *cobra.Command, defaultKinds []api.TriggerKind) {
+	cmd.Flags().BoolVar(&s.allViews, "all-views", false, "Select from all repositories and views.")
+	cmd.Flags().StringVar(&s.name, "name", "", "Select the alert or scheduled search with this name.")
+	cmd.Flags().StringSliceVar(&s.labels, "label", nil, "Select alerts and scheduled searches with this label. Can be repeated, in which case all labels must be present.")
+	addTriggerKindsFlag(cmd, &s.kinds, defaultKinds)
+}
+
+// addTriggerKindsFlag adds the --type flag restricting the kinds of alerts a command operates on.
+func addTriggerKindsFlag(cmd *cobra.Command, kinds *[]string, defaultKinds []api.TriggerKind) {
+	cmd.Flags().StringSliceVar(kinds, "type", triggerKindNames(defaultKinds), fmt.Sprintf("Kinds of alerts to select, any of %s.", strings.Join(triggerKindNames(api.TriggerKinds), ", ")))
+}
+
+// selectTriggers returns the alerts and scheduled searches in the search domains given by args or --all-views,
+// which match the name, labels and kinds of the selection.
+func (s *triggerSelection) selectTriggers(cmd *cobra.Command, client *api.Client, args []string) []api.Trigger {
+	if s.name == "" && len(s.labels) == 0 {
+		exitOnError(cmd, fmt.Errorf("select alerts with --name or --label"), "Invalid arguments")
+	}
+
+	var selected []api.Trigger
+	for _, trigger := range listTriggers(cmd, client, args, s.allViews, s.kinds) {
+		if s.matches(trigger) {
+			selected = append(selected, trigger)
+		}
+	}
+	if len(selected) == 0 {
+		exitOnError(cmd, fmt.Errorf("no alerts or scheduled searches match the selection"), "Error selecting alerts")
+	}
+	return selected
+}
+
+func (s *triggerSelection) matches(trigger api.Trigger) bool {
+	if s.name != "" && trigger.Name != s.name {
+		return false
+	}
+	for _, label := range s.labels {
+		if !trigger.HasLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// listTriggers returns the alerts and scheduled searches of the given kinds in the search domains given by args or allViews.
+func listTriggers(cmd *cobra.Command, client *api.Client, args []string, allViews bool, kindNames []string) []api.Trigger {
+	var kinds []api.TriggerKind
+	for _, kind := range kindNames {
+		if !containsTriggerKind(api.TriggerKinds, api.TriggerKind(kind)) {
+			exitOnError(cmd, fmt.Errorf("unknown type %q", kind), "Invalid arguments")
+		}
+		kinds = append(kinds, api.TriggerKind(kind))
+	}
+
+	views := searchDomainsFromArgs(cmd, client, args, allViews)
+	results := api.Bulk(context.Background(), views, bulkOptions(cmd), func(_ context.Context, view string) ([]api.Trigger, error) {
+		return client.Triggers().List(view, kinds)
+	})
+	exitOnBulkErrors(cmd, results, "Error fetching alerts")
+
+	var triggers []api.Trigger
+	for _, result := range results {
+		triggers = append(triggers, result.Value...)
+	}
+	return triggers
+}
+
+// triggerKindNames returns the names of kinds, as accepted by the --type flag.
+func triggerKindNames(kinds []api.TriggerKind) []string {
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = string(kind)
+	}
+	return names
+}
+
+func containsTriggerKind(kinds []api.TriggerKind, kind api.TriggerKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// setTriggersEnabled enables or disables each trigger not already in that state, and returns the ones which were changed.
+// Failures are reported as they happen and do not stop the remaining triggers from being changed, but changing stops
+// when ctx is cancelled. The returned error is set if any trigger failed or ctx was cancelled.
+func setTriggersEnabled(ctx context.Context, cmd *cobra.Command, client *api.Client, triggers []api.Trigger, enabled bool) ([]api.Trigger, error) {
+	verb := "disabled"
+	if enabled {
+		verb = "enabled"
+	}
+
+	var changed []api.Trigger
+	var failed bool
+	for _, trigger := range triggers {
+		if err := ctx.Err(); err != nil {
+			return changed, err
+		}
+		if trigger.Enabled == enabled {
+			fmt.Fprintf(cmd.OutOrStdout(), "The %s %q in %q is already %s\n", trigger.Kind, trigger.Name, trigger.SearchDomain, verb)
+			continue
+		}
+		if err := client.Triggers().SetEnabled(trigger, enabled); err != nil {
+			cmd.PrintErrf("Error changing %s %q in %q: %s\n", trigger.Kind, trigger.Name, trigger.SearchDomain, err)
+			failed = true
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Successfully %s %s %q in %q\n", verb, trigger.Kind, trigger.Name, trigger.SearchDomain)
+		changed = append(changed, trigger)
+	}
+	if failed {
+		return changed, fmt.Errorf("not all alerts could be %s", verb)
+	}
+	return changed, nil
+}
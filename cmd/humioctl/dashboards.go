@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newDashboardsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboards",
+		Short: "Manage dashboards",
+	}
+
+	cmd.AddCommand(newDashboardsListCmd())
+	cmd.AddCommand(newDashboardsShowCmd())
+	cmd.AddCommand(newDashboardsExportCmd())
+	cmd.AddCommand(newDashboardsExportAllCmd())
+	cmd.AddCommand(newDashboardsInstallCmd())
+	cmd.AddCommand(newDashboardsRemoveCmd())
+
+	return cmd
+}
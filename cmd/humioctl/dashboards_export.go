@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func newDashboardsExportCmd() *cobra.Command {
+	var outputName string
+
+	cmd := cobra.Command{
+		Use:   "export [flags] <view> <dashboard>",
+		Short: "Export a dashboard <dashboard> in <view> to a file.",
+		Long: `Export a dashboard to a YAML template file, in the same format as the export in the UI.
+The template includes the widgets with their queries, as well as the parameters of the dashboard.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			dashboardName := args[1]
+			client := NewApiClient(cmd)
+
+			if outputName == "" {
+				outputName = dashboardName
+			}
+
+			dashboard, err := client.Dashboards().Get(view, dashboardName)
+			exitOnError(cmd, err, "Error fetching dashboard")
+
+			template, err := client.Dashboards().Template(view, dashboard.ID)
+			exitOnError(cmd, err, "Error fetching dashboard template")
+
+			outFilePath := outputName + ".yaml"
+			err = os.WriteFile(outFilePath, []byte(template), 0600)
+			exitOnError(cmd, err, "Error saving the dashboard file")
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputName, "output", "o", "", "The file path where the dashboard should be written. Defaults to ./<dashboard-name>.yaml")
+
+	return &cmd
+}
+
+func newDashboardsExportAllCmd() *cobra.Command {
+	var outputDirectory string
+
+	cmd := cobra.Command{
+		Use:   "export-all <view>",
+		Short: "Export all dashboards",
+		Long:  `Export all dashboards to yaml template files with naming <sanitized-dashboard-name>.yaml. All non-alphanumeric characters will be replaced with underscore.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			client := NewApiClient(cmd)
+
+			dashboards, err := client.Dashboards().List(view)
+			exitOnError(cmd, err, "Error fetching dashboards")
+
+			for _, dashboard := range dashboards {
+				template, err := client.Dashboards().Template(view, dashboard.ID)
+				exitOnError(cmd, err, "Error fetching dashboard template")
+				dashboardFilename := sanitizeTriggerName(dashboard.Name) + ".yaml"
+
+				var outFilePath string
+				if outputDirectory != "" {
+					outFilePath = outputDirectory + "/" + dashboardFilename
+				} else {
+					outFilePath = dashboardFilename
+				}
+
+				err = os.WriteFile(outFilePath, []byte(template), 0600)
+				exitOnError(cmd, err, "Error saving the dashboard to file")
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputDirectory, "outputDirectory", "d", "", "The file path where the dashboards should be written. Defaults to current directory.")
+
+	return &cmd
+}
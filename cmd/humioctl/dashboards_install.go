@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newDashboardsInstallCmd() *cobra.Command {
+	var filePath, url, name string
+	var force bool
+
+	cmd := cobra.Command{
+		Use:   "install [flags] <view>",
+		Short: "Installs a dashboard in a view",
+		Long: `Install a dashboard from a URL or from a local file.
+
+The dashboard file is a YAML template in the same format as the export in the UI
+and the 'dashboards export' command, e.g.
+
+  $ humioctl dashboards install viewName --url=https://example.com/acme/dashboard.yaml
+
+  $ humioctl dashboards install viewName --file=./dashboard.yaml
+
+By default 'install' will not replace existing dashboards with the same name.
+Use the --force flag to replace them. Dashboard names are not unique, so --force
+fails rather than replacing one of several dashboards with the same name.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var content []byte
+			var err error
+
+			if filePath != "" {
+				content, err = getBytesFromFile(filePath)
+			} else if url != "" {
+				content, err = getBytesFromURL(url)
+			} else {
+				cmd.Printf("You must specify a path using --file or --url\n")
+				os.Exit(1)
+			}
+			exitOnError(cmd, err, "Could not load the dashboard")
+
+			client := NewApiClient(cmd)
+			viewName := args[0]
+
+			if name == "" {
+				var template struct {
+					Name string `yaml:"name"`
+				}
+				err = yaml.Unmarshal(content, &template)
+				exitOnError(cmd, err, "Could not unmarshal the dashboard")
+				name = template.Name
+			}
+			if name == "" {
+				exitOnError(cmd, fmt.Errorf("the dashboard template has no name"), "Use --name to name the dashboard")
+			}
+
+			existing, err := client.Dashboards().Get(viewName, name)
+			if err != nil && !errors.As(err, &api.EntityNotFound{}) {
+				exitOnError(cmd, err, "Could not check for existing dashboards")
+			}
+			if existing != nil && !force {
+				exitOnError(cmd, fmt.Errorf("dashboard %q already exists", name), "Use --force to replace it")
+			}
+
+			_, err = client.Dashboards().Create(viewName, name, string(content))
+			exitOnError(cmd, err, "Could not create the dashboard")
+
+			if existing != nil {
+				err = client.Dashboards().Delete(existing.ID)
+				exitOnError(cmd, err, "Could not remove the replaced dashboard")
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully installed dashboard %q in view %q\n", name, viewName)
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the dashboard to install.")
+	cmd.Flags().StringVar(&url, "url", "", "A URL to fetch the dashboard file from.")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Install the dashboard under a specific name, ignoring the `name` attribute in the dashboard file.")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace any existing dashboard with the same name.")
+	cmd.MarkFlagsMutuallyExclusive("file", "url")
+
+	return &cmd
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newDashboardsListCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "list <view>",
+		Short: "List all dashboards in a view.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			client := NewApiClient(cmd)
+
+			dashboards, err := client.Dashboards().List(view)
+			exitOnError(cmd, err, "Error fetching dashboards")
+
+			var rows = make([][]format.Value, len(dashboards))
+			for i := range dashboards {
+				dashboard := dashboards[i]
+				rows[i] = []format.Value{
+					format.String(dashboard.ID),
+					format.String(dashboard.Name),
+					format.StringPtr(dashboard.Description),
+					format.String(strings.Join(dashboard.Labels, ", ")),
+					format.Int(dashboard.WidgetCount),
+				}
+			}
+
+			printOverviewTable(cmd, []string{"ID", "Name", "Description", "Labels", "Widgets"}, rows)
+		},
+	}
+
+	return &cmd
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newDashboardsRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <view> <name>",
+		Short: "Removes a dashboard.",
+		Long:  `Removes the dashboard with name '<name>' in the view with name '<view>'.`,
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			viewName := args[0]
+			dashboardName := args[1]
+			client := NewApiClient(cmd)
+
+			dashboard, err := client.Dashboards().Get(viewName, dashboardName)
+			exitOnError(cmd, err, "Error fetching dashboard")
+
+			err = client.Dashboards().Delete(dashboard.ID)
+			exitOnError(cmd, err, "Could not remove dashboard")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully removed dashboard %q from view %q\n", dashboardName, viewName)
+		},
+	}
+
+	return cmd
+}
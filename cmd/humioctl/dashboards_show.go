@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newDashboardsShowCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "show <view> <name>",
+		Short: "Show details about a dashboard in a view.",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			name := args[1]
+			client := NewApiClient(cmd)
+
+			dashboard, err := client.Dashboards().Get(view, name)
+			exitOnError(cmd, err, "Error fetching dashboard")
+
+			details := [][]format.Value{
+				{format.String("ID"), format.String(dashboard.ID)},
+				{format.String("Name"), format.String(dashboard.Name)},
+				{format.String("Description"), format.StringPtr(dashboard.Description)},
+				{format.String("Labels"), format.String(strings.Join(dashboard.Labels, ", "))},
+				{format.String("Widgets"), format.Int(dashboard.WidgetCount)},
+				{format.String("Parameters"), format.Int(dashboard.ParameterCount)},
+			}
+
+			printDetailsTable(cmd, details)
+		},
+	}
+
+	return &cmd
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/humio/cli/internal/api"
@@ -68,39 +69,43 @@ func newFilterAlertsExportCmd() *cobra.Command {
 
 func newFilterAlertsExportAllCmd() *cobra.Command {
 	var outputDirectory string
+	var allViews bool
 
 	cmd := cobra.Command{
-		Use:   "export-all <view>",
+		Use:   "export-all (<view> | --all-views)",
 		Short: "Export all filter alerts",
-		Long:  `Export all filter alerts to yaml files with naming <sanitized-filter-alert-name>.yaml. All non-alphanumeric characters will be replaced with underscore.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Export all filter alerts to yaml files with naming <sanitized-filter-alert-name>.yaml. All non-alphanumeric characters will be replaced with underscore.
+
+With --all-views, the filter alerts of each repository and view are written to a subdirectory named <sanitized-view-name>.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			searchDomains := searchDomainsFromArgs(cmd, client, args, allViews)
 
-			var filterAlerts []api.FilterAlert
-			filterAlerts, err := client.FilterAlerts().List(view)
-			exitOnError(cmd, err, "Error fetching filter alerts")
-
-			for i := range filterAlerts {
-				yamlData, err := yaml.Marshal(&filterAlerts[i])
-				exitOnError(cmd, err, "Failed to serialize the filter alert")
-				filterAlertFilename := sanitizeTriggerName(filterAlerts[i].Name) + ".yaml"
-
-				var outFilePath string
-				if outputDirectory != "" {
-					outFilePath = outputDirectory + "/" + filterAlertFilename
-				} else {
-					outFilePath = filterAlertFilename
-				}
+			results := client.FilterAlerts().ListAll(context.Background(), searchDomains, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching filter alerts")
+			}
+
+			for _, result := range results {
+				filterAlerts := result.Value
+				for i := range filterAlerts {
+					yamlData, err := yaml.Marshal(&filterAlerts[i])
+					exitOnError(cmd, err, "Failed to serialize the filter alert")
+					filterAlertFilename := sanitizeTriggerName(filterAlerts[i].Name) + ".yaml"
 
-				err = os.WriteFile(outFilePath, yamlData, 0600)
-				exitOnError(cmd, err, "Error saving the filter alert to file")
+					outFilePath := exportFilePath(cmd, outputDirectory, result.Item, allViews, filterAlertFilename)
+					err = os.WriteFile(outFilePath, yamlData, 0600)
+					exitOnError(cmd, err, "Error saving the filter alert to file")
+				}
 			}
+
+			exitOnBulkErrors(cmd, results, "Error fetching filter alerts")
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputDirectory, "outputDirectory", "d", "", "The file path where the filter alerts should be written. Defaults to current directory.")
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "Export filter alerts from all repositories and views.")
 
 	return &cmd
 }
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"strings"
 
 	"github.com/humio/cli/internal/format"
@@ -22,37 +23,54 @@ import (
 )
 
 func newFilterAlertsListCmd() *cobra.Command {
+	var allViews bool
+
 	cmd := cobra.Command{
-		Use:   "list <view>",
+		Use:   "list [flags] (<view> | --all-views)",
 		Short: "List all filter alerts in a view.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			views := searchDomainsFromArgs(cmd, client, args, allViews)
+
+			results := client.FilterAlerts().ListAll(context.Background(), views, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching filter alerts")
+			}
 
-			filterAlerts, err := client.FilterAlerts().List(view)
-			exitOnError(cmd, err, "Error fetching filter alerts")
-
-			var rows = make([][]format.Value, len(filterAlerts))
-			for i := range filterAlerts {
-				filterAlert := filterAlerts[i]
-				rows[i] = []format.Value{
-					format.String(filterAlert.ID),
-					format.String(filterAlert.Name),
-					format.Bool(filterAlert.Enabled),
-					format.StringPtr(filterAlert.Description),
-					format.String(strings.Join(filterAlert.ActionNames, ", ")),
-					format.String(strings.Join(filterAlert.Labels, ", ")),
-					format.IntPtr(filterAlert.ThrottleTimeSeconds),
-					format.StringPtr(filterAlert.ThrottleField),
-					format.String(filterAlert.OwnershipRunAsID),
-					format.String(filterAlert.QueryOwnershipType),
+			var rows [][]format.Value
+			for _, result := range results {
+				for _, filterAlert := range result.Value {
+					row := []format.Value{
+						format.String(filterAlert.ID),
+						format.String(filterAlert.Name),
+						format.Bool(filterAlert.Enabled),
+						format.StringPtr(filterAlert.Description),
+						format.String(strings.Join(filterAlert.ActionNames, ", ")),
+						format.String(strings.Join(filterAlert.Labels, ", ")),
+						format.IntPtr(filterAlert.ThrottleTimeSeconds),
+						format.StringPtr(filterAlert.ThrottleField),
+						format.String(filterAlert.OwnershipRunAsID),
+						format.String(filterAlert.QueryOwnershipType),
+					}
+					if allViews {
+						row = append([]format.Value{format.String(result.Item)}, row...)
+					}
+					rows = append(rows, row)
 				}
 			}
 
-			printOverviewTable(cmd, []string{"ID", "Name", "Enabled", "Description", "Actions", "Labels", "Throttle Time Seconds", "Throttle Field", "Run As User ID", "Query Ownership Type"}, rows)
+			header := []string{"ID", "Name", "Enabled", "Description", "Actions", "Labels", "Throttle Time Seconds", "Throttle Field", "Run As User ID", "Query Ownership Type"}
+			if allViews {
+				header = append([]string{"View"}, header...)
+			}
+			printOverviewTable(cmd, header, rows)
+
+			exitOnBulkErrors(cmd, results, "Error fetching filter alerts")
 		},
 	}
 
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "List filter alerts in all repositories and views.")
+
 	return &cmd
 }
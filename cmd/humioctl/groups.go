@@ -11,6 +11,11 @@ func newGroupsCmd() *cobra.Command {
 	cmd.AddCommand(newGroupsAddUserCmd())
 	cmd.AddCommand(newGroupsRemoveUserCmd())
 	cmd.AddCommand(newGroupsList())
+	cmd.AddCommand(newGroupsShowCmd())
+	cmd.AddCommand(newGroupsCreateCmd())
+	cmd.AddCommand(newGroupsRenameCmd())
+	cmd.AddCommand(newGroupsDeleteCmd())
+	cmd.AddCommand(newGroupsSyncCmd())
 
 	return cmd
 }
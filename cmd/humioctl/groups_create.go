@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newGroupsCreateCmd() *cobra.Command {
+	var lookupName string
+
+	cmd := &cobra.Command{
+		Use:   "create <group>",
+		Short: "Create a group.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			groupName := args[0]
+			client := NewApiClient(cmd)
+
+			var lookupNamePtr *string
+			if lookupName != "" {
+				lookupNamePtr = &lookupName
+			}
+
+			group, err := client.Groups().Create(groupName, lookupNamePtr)
+			exitOnError(cmd, err, "Error creating group")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully created group %q with ID %q\n", group.DisplayName, group.ID)
+		},
+	}
+
+	cmd.Flags().StringVar(&lookupName, "lookup-name", "", "The name used to match the group with groups from an identity provider. Defaults to the group name.")
+
+	return cmd
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newGroupsDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <group>",
+		Short: "Delete a group.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			groupName := args[0]
+			client := NewApiClient(cmd)
+
+			err := client.Groups().Delete(groupName)
+			exitOnError(cmd, err, "Error deleting group")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted group %q\n", groupName)
+		},
+	}
+}
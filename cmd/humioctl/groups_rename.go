@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newGroupsRenameCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <group> <new-name>",
+		Short: "Rename a group.",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			groupName := args[0]
+			newGroupName := args[1]
+			client := NewApiClient(cmd)
+
+			_, err := client.Groups().Rename(groupName, newGroupName)
+			exitOnError(cmd, err, "Error renaming group")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully renamed group %q to %q\n", groupName, newGroupName)
+		},
+	}
+}
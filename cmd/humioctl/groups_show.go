@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newGroupsShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <group>",
+		Short: "Show the members and role assignments of a group.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			groupName := args[0]
+			client := NewApiClient(cmd)
+
+			group, err := client.Groups().Get(groupName)
+			exitOnError(cmd, err, "Error fetching group")
+
+			members := make([]string, len(group.Members))
+			for i, member := range group.Members {
+				members[i] = member.Username
+			}
+			sort.Strings(members)
+
+			details := [][]format.Value{
+				{format.String("Name"), format.String(group.DisplayName)},
+				{format.String("ID"), format.String(group.ID)},
+				{format.String("Lookup Name"), format.StringPtr(group.LookupName)},
+				{format.String("Members"), format.String(strings.Join(members, ", "))},
+				{format.String("Organization Roles"), format.String(strings.Join(group.OrganizationRoles, ", "))},
+				{format.String("System Roles"), format.String(strings.Join(group.SystemRoles, ", "))},
+			}
+
+			rolesByView := map[string][]string{}
+			var views []string
+			for _, viewRole := range group.ViewRoles {
+				if _, ok := rolesByView[viewRole.View]; !ok {
+					views = append(views, viewRole.View)
+				}
+				rolesByView[viewRole.View] = append(rolesByView[viewRole.View], viewRole.Role)
+			}
+			sort.Strings(views)
+			for _, view := range views {
+				details = append(details, []format.Value{
+					format.String(fmt.Sprintf("Roles in %s", view)),
+					format.String(strings.Join(rolesByView[view], ", ")),
+				})
+			}
+
+			printDetailsTable(cmd, details)
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newGroupsSyncCmd() *cobra.Command {
+	var filePath string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "sync --file <members.yaml>",
+		Short: "Synchronize group membership with a list of members.",
+		Long: `Add and remove group members so the members of each group in the file are exactly the listed users.
+Groups not mentioned in the file are left untouched, and all listed users must exist.
+
+The file maps group names to lists of usernames:
+
+  developers:
+    - alice@example.com
+    - bob@example.com
+  operators: []
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			if filePath == "" {
+				exitOnError(cmd, fmt.Errorf("--file is required"), "Error reading group members")
+			}
+			content, err := getBytesFromFile(filePath)
+			exitOnError(cmd, err, "Failed to load the members file")
+
+			var members map[string][]string
+			err = yaml.UnmarshalStrict(content, &members)
+			exitOnError(cmd, err, "Could not parse the members file")
+
+			groupNames := make([]string, 0, len(members))
+			for groupName := range members {
+				groupNames = append(groupNames, groupName)
+			}
+			sort.Strings(groupNames)
+
+			out := cmd.OutOrStdout()
+			for _, groupName := range groupNames {
+				changes, err := client.Groups().SyncMembers(groupName, members[groupName], dryRun)
+				exitOnError(cmd, err, fmt.Sprintf("Error synchronizing members of group %q", groupName))
+
+				addVerb, removeVerb := "Added", "Removed"
+				if dryRun {
+					addVerb, removeVerb = "Would add", "Would remove"
+				}
+				for _, username := range changes.Added {
+					fmt.Fprintf(out, "%s user %q to group %q\n", addVerb, username, groupName)
+				}
+				for _, username := range changes.Removed {
+					fmt.Fprintf(out, "%s user %q from group %q\n", removeVerb, username, groupName)
+				}
+			}
+
+			if !dryRun {
+				fmt.Fprintf(out, "Successfully synchronized %d groups\n", len(groupNames))
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to a YAML file mapping group names to usernames.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes without applying them.")
+
+	return cmd
+}
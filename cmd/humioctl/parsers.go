@@ -29,6 +29,9 @@ func newParsersCmd() *cobra.Command {
 	cmd.AddCommand(newParsersRemoveCmd())
 	cmd.AddCommand(newParsersExportCmd())
 	cmd.AddCommand(newParsersShowCmd())
+	cmd.AddCommand(newParsersTestCmd())
+	cmd.AddCommand(newParsersGenTestsCmd())
+	cmd.AddCommand(newParsersCloneCmd())
 
 	return cmd
 }
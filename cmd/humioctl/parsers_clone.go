@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newParsersCloneCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "clone <repo> <parser> <new-name>",
+		Short: "Create a copy of a parser under a new name.",
+		Long: `Create a copy of a parser, e.g. to experiment with changes without affecting ingest.
+
+Built-in parsers can be cloned as well. The copy is created in the same repository.`,
+		Args: cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			repoName := args[0]
+			parserName := args[1]
+			newName := args[2]
+			client := NewApiClient(cmd)
+
+			_, err := client.Parsers().Clone(repoName, parserName, newName)
+			exitOnError(cmd, err, "Error cloning parser")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully cloned parser %q to %q\n", parserName, newName)
+		},
+	}
+
+	return &cmd
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newParsersGenTestsCmd() *cobra.Command {
+	var samplesPath, filePath, outputName string
+	var ignoreFields []string
+	var appendTestCases bool
+
+	cmd := cobra.Command{
+		Use:   "gen-tests [flags] <repo> <parser>",
+		Short: "Generate parser test cases from sample log lines.",
+		Long: `Run each line of a samples file through the installed parser, and record the resulting fields as test cases.
+The test cases assert that each output event has the fields and values it has now, except for the ignored fields.
+
+By default the parser is exported with the generated test cases to ./<parser-name>.yaml.
+Use --file to replace the test cases of an existing parser file instead, keeping the rest of the file as is. E.g.
+
+  $ humioctl parsers gen-tests myrepo accesslog --samples ./access.log --file ./accesslog.yaml
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repositoryName := args[0]
+			parserName := args[1]
+
+			if samplesPath == "" {
+				exitOnError(cmd, fmt.Errorf("--samples is required"), "Error generating test cases")
+			}
+			samples, err := readSampleLines(samplesPath)
+			exitOnError(cmd, err, "Failed to load the samples")
+
+			client := NewApiClient(cmd)
+			parser, err := client.Parsers().Get(repositoryName, parserName)
+			exitOnError(cmd, err, "Error fetching parser")
+
+			sampleParser := *parser
+			sampleParser.TestCases = make([]api.ParserTestCase, len(samples))
+			for i, sample := range samples {
+				sampleParser.TestCases[i] = api.ParserTestCase{Event: api.ParserTestEvent{RawString: sample}}
+			}
+
+			results, err := client.Parsers().Test(repositoryName, &sampleParser)
+			exitOnError(cmd, err, "Error running the parser on the samples")
+
+			testCases := snapshotTestCases(results, ignoreFields)
+			for i, result := range results {
+				for _, failure := range result.Failures {
+					cmd.PrintErrf("Warning: sample %d: %s\n", i+1, formatParserTestFailure(failure))
+				}
+			}
+
+			if filePath != "" {
+				content, err := getParserFromFile(filePath)
+				exitOnError(cmd, err, "Failed to load the parser file")
+
+				content, err = replaceParserTestCases(content, testCases, appendTestCases)
+				exitOnError(cmd, err, "Error updating the parser file")
+
+				err = os.WriteFile(filePath, content, 0600)
+				exitOnError(cmd, err, "Error saving the parser file")
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Successfully wrote %d test cases to %s\n", len(testCases), filePath)
+				return
+			}
+
+			if appendTestCases {
+				parser.TestCases = append(parser.TestCases, testCases...)
+			} else {
+				parser.TestCases = testCases
+			}
+
+			yamlData, err := yaml.Marshal(parser)
+			exitOnError(cmd, err, "Failed to serialize the parser")
+
+			if outputName == "" {
+				outputName = parserName
+			}
+			outFilePath := outputName + ".yaml"
+			err = os.WriteFile(outFilePath, yamlData, 0600)
+			exitOnError(cmd, err, "Error saving the parser file")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully wrote %d test cases to %s\n", len(testCases), outFilePath)
+		},
+	}
+
+	cmd.Flags().StringVar(&samplesPath, "samples", "", "A file with one sample log line per line.")
+	cmd.Flags().StringVar(&filePath, "file", "", "A parser file to write the test cases to, replacing its current test cases.")
+	cmd.Flags().StringVarP(&outputName, "output", "o", "", "The file path where the parser should be written, unless --file is used. Defaults to ./<parser-name>.yaml")
+	cmd.Flags().StringSliceVar(&ignoreFields, "ignore-fields", []string{"@id", "@timestamp", "@timestamp.nanos", "@ingesttimestamp"}, "Fields which vary between runs and are left out of the assertions.")
+	cmd.Flags().BoolVar(&appendTestCases, "append", false, "Keep the existing test cases, and add the generated test cases after them.")
+	cmd.MarkFlagsMutuallyExclusive("file", "output")
+
+	return &cmd
+}
+
+func readSampleLines(path string) ([]string, error) {
+	content, err := getBytesFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			samples = append(samples, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no samples in %s", path)
+	}
+	return samples, nil
+}
+
+// snapshotTestCases turns the output of the parser on each sample into a test case asserting the same output.
+func snapshotTestCases(results []api.ParserTestCaseResult, ignoreFields []string) []api.ParserTestCase {
+	ignored := make(map[string]bool, len(ignoreFields))
+	for _, field := range ignoreFields {
+		ignored[field] = true
+	}
+
+	testCases := make([]api.ParserTestCase, len(results))
+	for i, result := range results {
+		testCases[i] = api.ParserTestCase{Event: api.ParserTestEvent{RawString: result.RawString}}
+		for index, event := range result.OutputEvents {
+			fieldsHaveValues := map[string]string{}
+			for field, value := range event {
+				if !ignored[field] {
+					fieldsHaveValues[field] = value
+				}
+			}
+			testCases[i].Assertions = append(testCases[i].Assertions, api.ParserTestCaseAssertions{
+				OutputEventIndex: index,
+				FieldsHaveValues: fieldsHaveValues,
+			})
+		}
+	}
+	return testCases
+}
+
+// replaceParserTestCases sets the test cases of a parser file, keeping its other contents and their order.
+func replaceParserTestCases(content []byte, testCases []api.ParserTestCase, appendTestCases bool) ([]byte, error) {
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	if appendTestCases {
+		var parser api.Parser
+		if err := yaml.Unmarshal(content, &parser); err != nil {
+			return nil, err
+		}
+		testCases = append(parser.TestCases, testCases...)
+	}
+
+	for i := range document {
+		if document[i].Key == "testCases" {
+			document[i].Value = testCases
+			return yaml.Marshal(document)
+		}
+	}
+	document = append(document, yaml.MapItem{Key: "testCases", Value: testCases})
+	return yaml.Marshal(document)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/humio/cli/internal/api"
+	"gopkg.in/yaml.v2"
+)
+
+func TestSnapshotTestCases(t *testing.T) {
+	testCases := snapshotTestCases([]api.ParserTestCaseResult{
+		{
+			RawString: "status=200",
+			OutputEvents: []map[string]string{
+				{"@rawstring": "status=200", "@timestamp": "1700000000000", "status": "200"},
+			},
+		},
+		{RawString: "dropped"},
+	}, []string{"@timestamp"})
+
+	expected := []api.ParserTestCase{
+		{
+			Event: api.ParserTestEvent{RawString: "status=200"},
+			Assertions: []api.ParserTestCaseAssertions{{
+				OutputEventIndex: 0,
+				FieldsHaveValues: map[string]string{"@rawstring": "status=200", "status": "200"},
+			}},
+		},
+		{Event: api.ParserTestEvent{RawString: "dropped"}},
+	}
+	if !reflect.DeepEqual(testCases, expected) {
+		t.Errorf("expected %+v, got %+v", expected, testCases)
+	}
+}
+
+func TestReplaceParserTestCases(t *testing.T) {
+	content := []byte(`name: accesslog
+script: kvParse()
+testCases:
+- event:
+    rawString: status=200
+  assertions:
+  - outputEventIndex: 0
+    fieldsHaveValues:
+      status: "200"
+tagFields:
+- status
+`)
+	generated := []api.ParserTestCase{{
+		Event: api.ParserTestEvent{RawString: "status=500"},
+		Assertions: []api.ParserTestCaseAssertions{{
+			OutputEventIndex: 0,
+			FieldsHaveValues: map[string]string{"status": "500"},
+		}},
+	}}
+
+	tests := []struct {
+		name       string
+		append     bool
+		rawStrings []string
+	}{
+		{"replace", false, []string{"status=500"}},
+		{"append", true, []string{"status=200", "status=500"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updated, err := replaceParserTestCases(content, generated, test.append)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var document yaml.MapSlice
+			if err := yaml.Unmarshal(updated, &document); err != nil {
+				t.Fatal(err)
+			}
+			var keys []string
+			for _, item := range document {
+				keys = append(keys, item.Key.(string))
+			}
+			if strings.Join(keys, ",") != "name,script,testCases,tagFields" {
+				t.Errorf("expected the order of the parser file to be kept, got %v", keys)
+			}
+
+			var parser api.Parser
+			if err := yaml.Unmarshal(updated, &parser); err != nil {
+				t.Fatal(err)
+			}
+			var rawStrings []string
+			for _, testCase := range parser.TestCases {
+				rawStrings = append(rawStrings, testCase.Event.RawString)
+			}
+			if !reflect.DeepEqual(rawStrings, test.rawStrings) {
+				t.Errorf("expected test cases %v, got %v", test.rawStrings, rawStrings)
+			}
+			last := parser.TestCases[len(parser.TestCases)-1]
+			if last.Assertions[0].FieldsHaveValues["status"] != "500" {
+				t.Errorf("expected the generated assertions to be written, got %+v", last.Assertions)
+			}
+		})
+	}
+
+	updated, err := replaceParserTestCases([]byte("name: accesslog\nscript: kvParse()\n"), generated, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parser api.Parser
+	if err := yaml.Unmarshal(updated, &parser); err != nil {
+		t.Fatal(err)
+	}
+	if parser.Script != "kvParse()" || len(parser.TestCases) != 1 {
+		t.Errorf("expected test cases to be added to a parser file without them, got:\n%s", updated)
+	}
+}
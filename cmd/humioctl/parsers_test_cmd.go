@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newParsersTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "test <repo> <file>",
+		Short: "Run the test cases of a parser file without installing the parser.",
+		Long: `Run the parser script of a parser file against the test cases in the file, and report which test cases pass.
+The parser is not installed. The command fails if any test case fails, so it can be used to check parser changes, e.g.
+
+  $ humioctl parsers test myrepo ./parser.yaml
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repositoryName := args[0]
+			filePath := args[1]
+
+			content, err := getParserFromFile(filePath)
+			exitOnError(cmd, err, "Failed to load the parser")
+
+			parser := api.Parser{}
+			err = yaml.Unmarshal(content, &parser)
+			exitOnError(cmd, err, "The parser's format was invalid")
+
+			if len(parser.TestCases) == 0 {
+				exitOnError(cmd, api.ValidationError{Message: "the parser file has no test cases"}, "Error testing parser")
+			}
+
+			client := NewApiClient(cmd)
+			results, err := client.Parsers().Test(repositoryName, &parser)
+			exitOnError(cmd, err, "Error testing parser")
+
+			out := cmd.OutOrStdout()
+			failed := 0
+			for i, result := range results {
+				status := "PASS"
+				if !result.Passed() {
+					status = "FAIL"
+					failed++
+				}
+				fmt.Fprintf(out, "%s  test case %d: %s\n", status, i+1, truncate(result.RawString, 80))
+
+				for _, failure := range result.Failures {
+					fmt.Fprintf(out, "      %s\n", formatParserTestFailure(failure))
+				}
+			}
+
+			fmt.Fprintf(out, "\n%d of %d test cases passed\n", len(results)-failed, len(results))
+			if failed > 0 {
+				exitOnError(cmd, fmt.Errorf("%d test cases failed", failed), "Parser tests failed")
+			}
+		},
+	}
+}
+
+func formatParserTestFailure(failure api.ParserTestFailure) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "output event %d", failure.OutputEventIndex)
+	if failure.Field != "" {
+		fmt.Fprintf(&b, ", field %q", failure.Field)
+	}
+	fmt.Fprintf(&b, ": %s", failure.Message)
+	if failure.Expected != nil {
+		fmt.Fprintf(&b, "\n        - expected: %q", *failure.Expected)
+		if failure.Actual != nil {
+			fmt.Fprintf(&b, "\n        + actual:   %q", *failure.Actual)
+		} else {
+			b.WriteString("\n        + actual:   (absent)")
+		}
+	} else if failure.Actual != nil {
+		fmt.Fprintf(&b, " (value %q)", *failure.Actual)
+	}
+	return b.String()
+}
+
+func truncate(s string, length int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length-1]) + "…"
+}
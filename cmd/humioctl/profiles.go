@@ -12,6 +12,7 @@ import (
 type login struct {
 	address       string
 	token         string
+	tokenCommand  string
 	username      string
 	caCertificate string
 	insecure      bool
@@ -19,14 +19,29 @@ import (
 
 // usersCmd represents the users command
 func newProfilesAddCmd() *cobra.Command {
+	var tokenCommand string
+
 	cmd := &cobra.Command{
 		Use:   "add <profile>",
 		Short: "Add a configuration profile",
-		Args:  cobra.ExactArgs(1),
+		Long: `Add a configuration profile.
+
+Instead of storing a static API token in the profile, you can specify a command
+which prints a short-lived token, e.g. from your own vault tooling:
+
+  $ humioctl profiles add prod --token-command="vault-humio-token --cluster prod"
+
+The command must print a JSON document on stdout:
+
+  {"token": "<api-token>", "expiry": "2024-01-01T12:00:00Z"}
+
+The token is cached until it expires, and the command is run again if the
+token is rejected by the server. The expiry is optional.`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			profileName := args[0]
 
-			profile, err := collectProfileInfo(cmd)
+			profile, err := collectProfileInfo(cmd, tokenCommand)
 			exitOnError(cmd, err, "Failed to collect profile info")
 
 			addAccount(profileName, profile)
@@ -38,6 +53,8 @@ func newProfilesAddCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&tokenCommand, "token-command", "", "A command printing a short-lived API token. Used instead of storing a static token in the profile.")
+
 	return cmd
 }
 
@@ -69,6 +86,7 @@ func addAccount(newName string, profile *login) {
 	profiles[newName] = map[string]interface{}{
 		viperkey.Address:       profile.address,
 		viperkey.Token:         profile.token,
+		viperkey.TokenCommand:  profile.tokenCommand,
 		viperkey.Username:      profile.username,
 		viperkey.CACertificate: profile.caCertificate,
 		viperkey.Insecure:      profile.insecure,
@@ -82,6 +100,7 @@ func mapToLogin(data interface{}) *login {
 		address:       getMapKeyString(data, viperkey.Address),
 		username:      getMapKeyString(data, viperkey.Username),
 		token:         getMapKeyString(data, viperkey.Token),
+		tokenCommand:  getMapKeyString(data, viperkey.TokenCommand),
 		caCertificate: getMapKeyString(data, viperkey.CACertificate),
 		insecure:      getMapKeyBool(data, viperkey.Insecure),
 	}
@@ -107,7 +126,7 @@ func getMapKeyBool(data interface{}, key string) bool {
 	return false
 }
 
-func collectProfileInfo(cmd *cobra.Command) (*login, error) {
+func collectProfileInfo(cmd *cobra.Command, tokenCommand string) (*login, error) {
 	var addr, token, username, caCertificate string
 	var insecure bool
 
@@ -205,6 +224,26 @@ func collectProfileInfo(cmd *cobra.Command) (*login, error) {
 		break
 	}
 
+	if tokenCommand != "" {
+		args, err := api.SplitCommandLine(tokenCommand)
+		exitOnError(cmd, err, "Invalid token command")
+		provider, err := api.NewExecTokenProvider(args, "")
+		exitOnError(cmd, err, "Invalid token command")
+
+		config := api.DefaultConfig()
+		config.Address = parsedURL
+		config.TokenProvider = provider
+		config.CACertificatePEM = caCertificate
+		config.Insecure = insecure
+
+		out.Print("==> Running token command...")
+		username, err = api.NewClient(config).Viewer().Username()
+		exitOnError(cmd, err, "Authentication using the token command failed")
+		cmd.Println(prompt.Colorize(fmt.Sprintf("==> Logged in as: [purple]%s[reset]", username)))
+
+		return &login{address: addr, tokenCommand: tokenCommand, username: username, caCertificate: caCertificate, insecure: insecure}, nil
+	}
+
 	out.Info("Paste in your Personal API Token")
 	out.Description("To use Humio's CLI you will need to get a copy of your API Token.")
 	out.Description("The API token can be found on the 'Account Settings' page of the UI.")
@@ -20,6 +20,7 @@ func newProfilesSetDefaultCmd() *cobra.Command {
 			exitOnError(cmd, err, "Profile not found")
 			viper.Set(viperkey.Address, profile.address)
 			viper.Set(viperkey.Token, profile.token)
+			viper.Set(viperkey.TokenCommand, profile.tokenCommand)
 			viper.Set(viperkey.CACertificateFile, profile.caCertificate)
 			viper.Set(viperkey.Insecure, profile.insecure)
 
@@ -46,6 +47,7 @@ func loadProfile(profileName string) (*login, error) {
 	profile := login{
 		address:       getMapKeyString(profileData, viperkey.Address),
 		token:         getMapKeyString(profileData, viperkey.Token),
+		tokenCommand:  getMapKeyString(profileData, viperkey.TokenCommand),
 		caCertificate: getMapKeyString(profileData, viperkey.CACertificate),
 		insecure:      insecureFromProfileData,
 	}
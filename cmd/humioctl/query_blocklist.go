@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newQueryBlocklistCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "query-blocklist",
+		Aliases: []string{"query-blacklist"},
+		Short:   "Manage the query blocklist",
+		Long: `Manage the patterns of queries which are not allowed to run.
+
+Exact patterns block queries whose query string equals the pattern, and regex patterns block
+queries whose query string matches the regular expression. Patterns apply to the whole organization,
+unless they are limited to a repository or view with --view, or apply to the whole cluster with --cluster-wide.
+`,
+	}
+
+	cmd.AddCommand(newQueryBlocklistListCmd())
+	cmd.AddCommand(newQueryBlocklistAddCmd())
+	cmd.AddCommand(newQueryBlocklistUpdateCmd())
+	cmd.AddCommand(newQueryBlocklistRemoveCmd())
+	cmd.AddCommand(newQueryBlocklistExportCmd())
+	cmd.AddCommand(newQueryBlocklistInstallCmd())
+
+	return cmd
+}
+
+// sameBlockedQuery reports whether two entries block the same queries in the same scope.
+func sameBlockedQuery(a, b api.BlockedQuery) bool {
+	sameView := (a.View == nil && b.View == nil) || (a.View != nil && b.View != nil && *a.View == *b.View)
+	return a.Pattern == b.Pattern && strings.EqualFold(a.Type, b.Type) && sameView && a.ClusterWide == b.ClusterWide
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+type blockedQueryFlags struct {
+	matcherType string
+	view        string
+	clusterWide bool
+}
+
+func (f *blockedQueryFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.matcherType, "type", "exact", "How the pattern matches query strings, either exact or regex.")
+	cmd.Flags().StringVar(&f.view, "view", "", "Only block the pattern in this repository or view.")
+	cmd.Flags().BoolVar(&f.clusterWide, "cluster-wide", false, "Block the pattern in all organizations of the cluster.")
+	cmd.MarkFlagsMutuallyExclusive("view", "cluster-wide")
+}
+
+func (f *blockedQueryFlags) blockedQuery(pattern string) api.BlockedQuery {
+	blockedQuery := api.BlockedQuery{
+		Pattern:     pattern,
+		Type:        f.matcherType,
+		ClusterWide: f.clusterWide,
+	}
+	if f.view != "" {
+		blockedQuery.View = &f.view
+	}
+	return blockedQuery
+}
+
+func newQueryBlocklistAddCmd() *cobra.Command {
+	var flags blockedQueryFlags
+
+	cmd := &cobra.Command{
+		Use:   "add <pattern>",
+		Short: "Block queries matching a pattern.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			blockedQuery, err := client.QueryBlocklist().Add(flags.blockedQuery(args[0]))
+			exitOnError(cmd, err, "Error adding pattern to the query blocklist")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully blocked pattern %q with ID %q\n", blockedQuery.Pattern, blockedQuery.ID)
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newQueryBlocklistExportCmd() *cobra.Command {
+	var outputName string
+	var clusterWide bool
+
+	cmd := cobra.Command{
+		Use:   "export",
+		Short: "Export the query blocklist to a file.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			blockedQueries, err := client.QueryBlocklist().List(clusterWide)
+			exitOnError(cmd, err, "Error fetching the query blocklist")
+
+			yamlData, err := yaml.Marshal(blockedQueries)
+			exitOnError(cmd, err, "Failed to serialize the query blocklist")
+
+			outFilePath := outputName + ".yaml"
+			err = os.WriteFile(outFilePath, yamlData, 0600)
+			exitOnError(cmd, err, "Error saving the query blocklist file")
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputName, "output", "o", "query-blocklist", "The file path where the query blocklist should be written, without the .yaml extension.")
+	cmd.Flags().BoolVar(&clusterWide, "cluster-wide", false, "Include cluster wide patterns.")
+
+	return &cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newQueryBlocklistInstallCmd() *cobra.Command {
+	var filePath, url string
+
+	cmd := cobra.Command{
+		Use:   "install",
+		Short: "Add the patterns of a query blocklist file.",
+		Long: `Add the patterns of a query blocklist file which are not already blocked. Other patterns are left untouched.
+
+The file is a list of patterns, as written by the export command:
+
+  - pattern: "* | groupBy(field=@rawstring)"
+    type: EXACT
+  - pattern: "^count\\(\\)$"
+    type: REGEX
+    view: humio-audit
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var content []byte
+			var err error
+
+			if filePath != "" {
+				content, err = getBytesFromFile(filePath)
+			} else if url != "" {
+				content, err = getBytesFromURL(url)
+			} else {
+				cmd.Printf("You must specify a path using --file or --url\n")
+				os.Exit(1)
+			}
+			exitOnError(cmd, err, "Could not load the query blocklist")
+
+			client := NewApiClient(cmd)
+
+			var blockedQueries []api.BlockedQuery
+			err = yaml.UnmarshalStrict(content, &blockedQueries)
+			exitOnError(cmd, err, "Could not unmarshal the query blocklist")
+
+			existing, err := client.QueryBlocklist().List(true)
+			exitOnError(cmd, err, "Error fetching the query blocklist")
+
+			added := 0
+		patterns:
+			for _, blockedQuery := range blockedQueries {
+				for _, e := range existing {
+					if sameBlockedQuery(e, blockedQuery) {
+						continue patterns
+					}
+				}
+
+				_, err := client.QueryBlocklist().Add(blockedQuery)
+				exitOnError(cmd, err, fmt.Sprintf("Error adding pattern %q to the query blocklist", blockedQuery.Pattern))
+				added++
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully added %d of %d patterns to the query blocklist\n", added, len(blockedQueries))
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the query blocklist to install.")
+	cmd.Flags().StringVar(&url, "url", "", "A URL to fetch the query blocklist file from.")
+	cmd.MarkFlagsMutuallyExclusive("file", "url")
+
+	return &cmd
+}
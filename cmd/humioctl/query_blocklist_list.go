@@ -0,0 +1,57 @@
+package main
+
+import (
+	"time"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newQueryBlocklistListCmd() *cobra.Command {
+	var view string
+	var clusterWide bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List blocked query patterns.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			blockedQueries, err := client.QueryBlocklist().List(clusterWide)
+			exitOnError(cmd, err, "Error listing blocked queries")
+
+			var rows [][]format.Value
+			for _, blockedQuery := range blockedQueries {
+				if view != "" && (blockedQuery.View == nil || *blockedQuery.View != view) {
+					continue
+				}
+
+				scope := "organization"
+				if blockedQuery.ClusterWide {
+					scope = "cluster"
+				}
+				expiresAt := "never"
+				if blockedQuery.ExpiresAt != nil {
+					expiresAt = blockedQuery.ExpiresAt.UTC().Format(time.RFC3339)
+				}
+
+				rows = append(rows, []format.Value{
+					format.String(blockedQuery.ID),
+					format.String(blockedQuery.Type),
+					format.String(blockedQuery.Pattern),
+					format.StringPtr(blockedQuery.View),
+					format.String(scope),
+					format.String(expiresAt),
+				})
+			}
+
+			printOverviewTable(cmd, []string{"ID", "Type", "Pattern", "View", "Scope", "Expires At"}, rows)
+		},
+	}
+
+	cmd.Flags().StringVar(&view, "view", "", "Only list patterns limited to this repository or view.")
+	cmd.Flags().BoolVar(&clusterWide, "cluster-wide", false, "Include cluster wide patterns.")
+
+	return cmd
+}
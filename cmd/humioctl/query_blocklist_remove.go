@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newQueryBlocklistRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <id>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a pattern from the query blocklist.",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			client := NewApiClient(cmd)
+
+			err := client.QueryBlocklist().Remove(id)
+			exitOnError(cmd, err, "Error removing pattern from the query blocklist")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully removed pattern %q from the query blocklist\n", id)
+		},
+	}
+}
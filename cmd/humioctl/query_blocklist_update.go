@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newQueryBlocklistUpdateCmd() *cobra.Command {
+	var flags blockedQueryFlags
+
+	cmd := &cobra.Command{
+		Use:   "update <id> <pattern>",
+		Short: "Replace a blocked query pattern.",
+		Long: `Replace the blocked query pattern with the given ID.
+
+The existing pattern is removed after the new pattern has been added, so the new pattern gets a new ID.
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			client := NewApiClient(cmd)
+
+			blockedQuery, err := client.QueryBlocklist().Add(flags.blockedQuery(args[1]))
+			exitOnError(cmd, err, "Error adding pattern to the query blocklist")
+
+			err = client.QueryBlocklist().Remove(id)
+			exitOnError(cmd, err, "Error removing the previous pattern from the query blocklist")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully replaced pattern %q with pattern %q with ID %q\n", id, blockedQuery.Pattern, blockedQuery.ID)
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
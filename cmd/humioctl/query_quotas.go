@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newQueryQuotasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-quotas",
+		Short: "Manage query quotas",
+		Long: `Manage the limits on how many queries users can run, and how much they can cost.
+
+Each quota setting limits a measurement (QueryCount, StaticCost or LiveCost) within an interval
+(PerMinute, PerTenMinutes, PerHour or PerDay). The default settings apply to all users
+without settings of their own.
+`,
+	}
+
+	cmd.AddCommand(newQueryQuotasListCmd())
+	cmd.AddCommand(newQueryQuotasShowCmd())
+	cmd.AddCommand(newQueryQuotasSetCmd())
+	cmd.AddCommand(newQueryQuotasRemoveCmd())
+	cmd.AddCommand(newQueryQuotasExportCmd())
+	cmd.AddCommand(newQueryQuotasInstallCmd())
+
+	return cmd
+}
+
+// queryQuotasFile is the format of the files written by export and read by install.
+type queryQuotasFile struct {
+	Defaults []api.QueryQuotaSetting            `yaml:"defaults,omitempty"`
+	Users    map[string][]api.QueryQuotaSetting `yaml:"users,omitempty"`
+}
+
+func queryQuotaRow(user string, setting api.QueryQuotaSetting) []format.Value {
+	limit := "unlimited"
+	if setting.Value != nil {
+		limit = strconv.FormatInt(*setting.Value, 10)
+	}
+
+	return []format.Value{
+		format.String(user),
+		format.String(setting.Interval),
+		format.String(setting.MeasurementKind),
+		format.String(limit),
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newQueryQuotasExportCmd() *cobra.Command {
+	var outputName string
+
+	cmd := cobra.Command{
+		Use:   "export",
+		Short: "Export the default query quotas and the query quotas of users to a file.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			defaults, err := client.QueryQuotas().Defaults()
+			exitOnError(cmd, err, "Error fetching default query quotas")
+
+			users, err := client.QueryQuotas().List("")
+			exitOnError(cmd, err, "Error fetching query quotas")
+
+			quotas := queryQuotasFile{Defaults: defaults, Users: map[string][]api.QueryQuotaSetting{}}
+			for _, user := range users {
+				for _, setting := range user.Settings {
+					if setting.UserSpecified {
+						quotas.Users[user.Username] = append(quotas.Users[user.Username], setting)
+					}
+				}
+			}
+
+			yamlData, err := yaml.Marshal(quotas)
+			exitOnError(cmd, err, "Failed to serialize the query quotas")
+
+			outFilePath := outputName + ".yaml"
+			err = os.WriteFile(outFilePath, yamlData, 0600)
+			exitOnError(cmd, err, "Error saving the query quotas file")
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputName, "output", "o", "query-quotas", "The file path where the query quotas should be written, without the .yaml extension.")
+
+	return &cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newQueryQuotasInstallCmd() *cobra.Command {
+	var filePath, url string
+
+	cmd := cobra.Command{
+		Use:   "install",
+		Short: "Set the query quotas of a query quotas file.",
+		Long: `Set the default query quotas and the query quotas of users from a file, as written by the export command.
+Settings which are not in the file, and users not in the file, are left untouched.
+
+  defaults:
+    - interval: PerHour
+      measurementKind: QueryCount
+      value: 1000
+  users:
+    alice@example.com:
+      - interval: PerDay
+        measurementKind: StaticCost
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var content []byte
+			var err error
+
+			if filePath != "" {
+				content, err = getBytesFromFile(filePath)
+			} else if url != "" {
+				content, err = getBytesFromURL(url)
+			} else {
+				cmd.Printf("You must specify a path using --file or --url\n")
+				os.Exit(1)
+			}
+			exitOnError(cmd, err, "Could not load the query quotas")
+
+			client := NewApiClient(cmd)
+
+			var quotas queryQuotasFile
+			err = yaml.UnmarshalStrict(content, &quotas)
+			exitOnError(cmd, err, "Could not unmarshal the query quotas")
+
+			if len(quotas.Defaults) > 0 {
+				err = client.QueryQuotas().SetDefaults(quotas.Defaults)
+				exitOnError(cmd, err, "Error setting default query quotas")
+			}
+
+			usernames := make([]string, 0, len(quotas.Users))
+			for username := range quotas.Users {
+				usernames = append(usernames, username)
+			}
+			sort.Strings(usernames)
+			for _, username := range usernames {
+				err = client.QueryQuotas().SetForUser(username, quotas.Users[username])
+				exitOnError(cmd, err, fmt.Sprintf("Error setting query quotas for user %q", username))
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully installed query quotas for %d users\n", len(usernames))
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the query quotas to install.")
+	cmd.Flags().StringVar(&url, "url", "", "A URL to fetch the query quotas file from.")
+	cmd.MarkFlagsMutuallyExclusive("file", "url")
+
+	return &cmd
+}
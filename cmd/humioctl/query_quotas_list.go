@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newQueryQuotasListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the default query quotas and the users with query quotas of their own.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			defaults, err := client.QueryQuotas().Defaults()
+			exitOnError(cmd, err, "Error fetching default query quotas")
+
+			users, err := client.QueryQuotas().List("")
+			exitOnError(cmd, err, "Error listing query quotas")
+
+			var rows [][]format.Value
+			for _, setting := range defaults {
+				rows = append(rows, queryQuotaRow("(default)", setting))
+			}
+			for _, user := range users {
+				for _, setting := range user.Settings {
+					if setting.UserSpecified {
+						rows = append(rows, queryQuotaRow(user.Username, setting))
+					}
+				}
+			}
+
+			printOverviewTable(cmd, []string{"User", "Interval", "Measurement", "Limit"}, rows)
+		},
+	}
+}
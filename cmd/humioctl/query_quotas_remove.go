@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newQueryQuotasRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <username>",
+		Short: "Remove the query quotas of a user, so the default query quotas apply to them.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			username := args[0]
+			client := NewApiClient(cmd)
+
+			err := client.QueryQuotas().RemoveForUser(username)
+			exitOnError(cmd, err, "Error removing query quotas")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully removed query quotas for user %q\n", username)
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newQueryQuotasSetCmd() *cobra.Command {
+	var interval, measurement string
+	var limit int64
+	var unlimited, setDefault bool
+
+	cmd := &cobra.Command{
+		Use:   "set (<username> | --default)",
+		Short: "Set a query quota for a user, or the default query quota.",
+		Long: `Set the limit of a measurement within an interval, for a user or for all users without settings of their own.
+Settings for other intervals and measurements are kept. E.g.
+
+  $ humioctl query-quotas set alice@example.com --interval PerHour --measurement QueryCount --limit 100
+
+  $ humioctl query-quotas set --default --interval PerDay --measurement StaticCost --unlimited
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if setDefault {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			if !unlimited && !cmd.Flags().Changed("limit") {
+				exitOnError(cmd, fmt.Errorf("either --limit or --unlimited is required"), "Error setting query quota")
+			}
+
+			setting := api.QueryQuotaSetting{
+				Interval:        interval,
+				MeasurementKind: measurement,
+			}
+			if !unlimited {
+				setting.Value = &limit
+			}
+
+			if setDefault {
+				err := client.QueryQuotas().SetDefaults([]api.QueryQuotaSetting{setting})
+				exitOnError(cmd, err, "Error setting default query quota")
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Successfully set default %s quota %s\n", measurement, interval)
+				return
+			}
+
+			username := args[0]
+			err := client.QueryQuotas().SetForUser(username, []api.QueryQuotaSetting{setting})
+			exitOnError(cmd, err, "Error setting query quota")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully set %s quota %s for user %q\n", measurement, interval, username)
+		},
+	}
+
+	cmd.Flags().BoolVar(&setDefault, "default", false, "Set the default quota instead of the quota of a user.")
+	cmd.Flags().StringVar(&interval, "interval", "", "The interval of the quota, one of PerMinute, PerTenMinutes, PerHour or PerDay.")
+	cmd.Flags().StringVar(&measurement, "measurement", "", "What the quota limits, one of QueryCount, StaticCost or LiveCost.")
+	cmd.Flags().Int64Var(&limit, "limit", 0, "The maximum value of the measurement within the interval.")
+	cmd.Flags().BoolVar(&unlimited, "unlimited", false, "Remove the limit of the measurement within the interval.")
+	cmd.MarkFlagsMutuallyExclusive("limit", "unlimited")
+	_ = cmd.MarkFlagRequired("interval")
+	_ = cmd.MarkFlagRequired("measurement")
+
+	return cmd
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newQueryQuotasShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <username>",
+		Short: "Show the query quotas that apply to a user.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			username := args[0]
+			client := NewApiClient(cmd)
+
+			users, err := client.QueryQuotas().List(username)
+			exitOnError(cmd, err, "Error fetching query quotas")
+			if len(users) == 0 {
+				exitOnError(cmd, api.UserNotFound(username), "Error fetching query quotas")
+			}
+
+			var rows [][]format.Value
+			for _, setting := range users[0].Settings {
+				source := "default"
+				if setting.UserSpecified {
+					source = "user"
+				}
+				rows = append(rows, append(queryQuotaRow(username, setting), format.String(source)))
+			}
+
+			printOverviewTable(cmd, []string{"User", "Interval", "Measurement", "Limit", "Source"}, rows)
+		},
+	}
+}
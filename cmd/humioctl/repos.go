@@ -31,6 +31,7 @@ func newReposCmd() *cobra.Command {
 	cmd.AddCommand(newReposCreateCmd())
 	cmd.AddCommand(newReposUpdateCmd())
 	cmd.AddCommand(newReposDeleteCmd())
+	cmd.AddCommand(newReposRenameCmd())
 
 	return cmd
 }
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newReposRenameCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "rename <repo> <new-name>",
+		Short: "Rename a repository.",
+		Long: `Rename a repository.
+
+Queries of alerts and scheduled searches, and templates of actions, are not updated.
+A warning is printed for each of them which mentions the old name.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			repoName := args[0]
+			newName := args[1]
+			client := NewApiClient(cmd)
+
+			_, err := client.Repositories().Get(repoName)
+			exitOnError(cmd, err, "Error fetching repository")
+
+			renameSearchDomain(cmd, client, "repository", repoName, newName)
+		},
+	}
+
+	return &cmd
+}
@@ -0,0 +1,20 @@
+package main
+
+import "github.com/spf13/cobra"
+
+func newRolesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "roles",
+		Short: "Manage roles",
+	}
+
+	cmd.AddCommand(newRolesListCmd())
+	cmd.AddCommand(newRolesShowCmd())
+	cmd.AddCommand(newRolesCreateCmd())
+	cmd.AddCommand(newRolesUpdateCmd())
+	cmd.AddCommand(newRolesDeleteCmd())
+	cmd.AddCommand(newRolesAssignCmd())
+	cmd.AddCommand(newRolesUnassignCmd())
+
+	return cmd
+}
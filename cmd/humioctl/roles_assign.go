@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// roleAssignment is the scope of a role assignment to a group, given with exactly one of --view, --organization or --system.
+type roleAssignment struct {
+	view         string
+	organization bool
+	system       bool
+}
+
+func (a *roleAssignment) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&a.view, "view", "", "The repository or view the role applies to.")
+	cmd.Flags().BoolVar(&a.organization, "organization", false, "Use the organization permissions of the role.")
+	cmd.Flags().BoolVar(&a.system, "system", false, "Use the system permissions of the role.")
+	cmd.MarkFlagsMutuallyExclusive("view", "organization", "system")
+}
+
+func (a *roleAssignment) String() string {
+	switch {
+	case a.organization:
+		return "the organization"
+	case a.system:
+		return "the system"
+	default:
+		return fmt.Sprintf("view %q", a.view)
+	}
+}
+
+// resolve returns the IDs of the role, the group and, for view assignments, the view.
+func (a *roleAssignment) resolve(cmd *cobra.Command, client *api.Client, roleName, groupName string) (string, string, string) {
+	if a.view == "" && !a.organization && !a.system {
+		exitOnError(cmd, fmt.Errorf("one of --view, --organization or --system is required"), "Invalid arguments")
+	}
+
+	role, err := client.Roles().Get(roleName)
+	exitOnError(cmd, err, "Error fetching role")
+
+	groupID, err := client.Groups().GetGroupID(groupName)
+	exitOnError(cmd, err, "Error fetching group")
+
+	var viewID string
+	if a.view != "" {
+		searchDomain, err := client.SearchDomains().Get(a.view)
+		exitOnError(cmd, err, "Error fetching view")
+		viewID = searchDomain.ID
+	}
+
+	return role.ID, groupID, viewID
+}
+
+func newRolesAssignCmd() *cobra.Command {
+	var assignment roleAssignment
+
+	cmd := &cobra.Command{
+		Use:   "assign <role> <group> (--view <view> | --organization | --system)",
+		Short: "Assign a role to a group.",
+		Long: `Assign a role to a group, giving its members the permissions of the role.
+
+With --view, the members get the view permissions of the role in that repository or view.
+With --organization or --system, they get the organization or system permissions of the role.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			roleName := args[0]
+			groupName := args[1]
+			client := NewApiClient(cmd)
+
+			roleID, groupID, viewID := assignment.resolve(cmd, client, roleName, groupName)
+
+			var err error
+			switch {
+			case assignment.organization:
+				err = client.Roles().AssignToGroupForOrganization(roleID, groupID)
+			case assignment.system:
+				err = client.Roles().AssignToGroupForSystem(roleID, groupID)
+			default:
+				err = client.Roles().AssignToGroupForView(roleID, groupID, viewID)
+			}
+			exitOnError(cmd, err, "Error assigning role")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully assigned role %q to group %q for %s\n", roleName, groupName, assignment.String())
+		},
+	}
+
+	assignment.addFlags(cmd)
+
+	return cmd
+}
+
+func newRolesUnassignCmd() *cobra.Command {
+	var assignment roleAssignment
+
+	cmd := &cobra.Command{
+		Use:   "unassign <role> <group> (--view <view> | --organization | --system)",
+		Short: "Remove a role from a group.",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			roleName := args[0]
+			groupName := args[1]
+			client := NewApiClient(cmd)
+
+			roleID, groupID, viewID := assignment.resolve(cmd, client, roleName, groupName)
+
+			var err error
+			switch {
+			case assignment.organization:
+				err = client.Roles().UnassignFromGroupForOrganization(roleID, groupID)
+			case assignment.system:
+				err = client.Roles().UnassignFromGroupForSystem(roleID, groupID)
+			default:
+				err = client.Roles().UnassignFromGroupForView(roleID, groupID, viewID)
+			}
+			exitOnError(cmd, err, "Error removing role")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully removed role %q from group %q for %s\n", roleName, groupName, assignment.String())
+		},
+	}
+
+	assignment.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const roleFileHelp = `The role file is YAML listing the permissions of the role, e.g.
+
+  name: Log readers
+  description: Can search all repositories
+  viewPermissions:
+    - ReadAccess
+  organizationPermissions: []
+  systemPermissions: []
+
+Permissions are named as in the GraphQL API, e.g. ReadAccess, ChangeParsers or ManageUsers.`
+
+func newRolesCreateCmd() *cobra.Command {
+	var filePath, name string
+
+	cmd := &cobra.Command{
+		Use:   "create --file <role.yaml>",
+		Short: "Create a role.",
+		Long: `Create a role from a file.
+
+` + roleFileHelp,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			role := readRoleFile(cmd, filePath)
+			if name != "" {
+				role.DisplayName = name
+			}
+
+			client := NewApiClient(cmd)
+			_, err := client.Roles().Create(&role)
+			exitOnError(cmd, err, "Error creating role")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully created role %q\n", role.DisplayName)
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the role to create.")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Create the role under a specific name, ignoring the `name` attribute in the role file.")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func readRoleFile(cmd *cobra.Command, filePath string) api.Role {
+	content, err := getBytesFromFile(filePath)
+	exitOnError(cmd, err, "Could not load the role")
+
+	var role api.Role
+	err = yaml.UnmarshalStrict(content, &role)
+	exitOnError(cmd, err, "Could not unmarshal the role")
+
+	if role.ViewPermissions == nil {
+		role.ViewPermissions = []string{}
+	}
+	return role
+}
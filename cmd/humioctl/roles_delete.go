@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newRolesDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <role>",
+		Short: "Delete a role.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			roleName := args[0]
+			client := NewApiClient(cmd)
+
+			err := client.Roles().Delete(roleName)
+			exitOnError(cmd, err, "Error deleting role")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted role %q\n", roleName)
+		},
+	}
+}
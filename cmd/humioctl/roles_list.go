@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newRolesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List roles",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			roles, err := client.Roles().List()
+			exitOnError(cmd, err, "Error listing roles")
+
+			rows := make([][]format.Value, len(roles))
+			for i, role := range roles {
+				rows[i] = []format.Value{
+					format.String(role.DisplayName),
+					format.String(role.ID),
+					format.String(strings.Join(role.ViewPermissions, ", ")),
+					format.String(strings.Join(role.OrgPermissions, ", ")),
+					format.String(strings.Join(role.SystemPermissions, ", ")),
+				}
+			}
+
+			printOverviewTable(cmd, []string{"Name", "ID", "View Permissions", "Organization Permissions", "System Permissions"}, rows)
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newRolesShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <role>",
+		Short: "Show details about a role.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			roleName := args[0]
+			client := NewApiClient(cmd)
+
+			role, err := client.Roles().Get(roleName)
+			exitOnError(cmd, err, "Error fetching role")
+
+			details := [][]format.Value{
+				{format.String("Name"), format.String(role.DisplayName)},
+				{format.String("ID"), format.String(role.ID)},
+				{format.String("Description"), format.StringPtr(role.Description)},
+				{format.String("View Permissions"), format.String(strings.Join(role.ViewPermissions, ", "))},
+				{format.String("Organization Permissions"), format.String(strings.Join(role.OrgPermissions, ", "))},
+				{format.String("System Permissions"), format.String(strings.Join(role.SystemPermissions, ", "))},
+			}
+
+			printDetailsTable(cmd, details)
+		},
+	}
+}
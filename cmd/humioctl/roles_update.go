@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newRolesUpdateCmd() *cobra.Command {
+	var filePath string
+
+	cmd := &cobra.Command{
+		Use:   "update <role> --file <role.yaml>",
+		Short: "Update a role.",
+		Long: `Replace the name and permissions of a role with those in a file.
+If the file has no name, the role keeps its current name.
+
+` + roleFileHelp,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			roleName := args[0]
+			role := readRoleFile(cmd, filePath)
+			if role.DisplayName == "" {
+				role.DisplayName = roleName
+			}
+
+			client := NewApiClient(cmd)
+			_, err := client.Roles().Update(roleName, &role)
+			exitOnError(cmd, err, "Error updating role")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully updated role %q\n", role.DisplayName)
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the role definition.")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
@@ -15,7 +15,10 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -27,9 +30,13 @@ import (
 	"github.com/spf13/viper"
 )
 
-var cfgFile, tokenFile, token, address, caCertificateFile, profileFlag, proxyOrganization string
+var cfgFile, tokenFile, token, tokenCommand, address, caCertificateFile, profileFlag, proxyOrganization string
 var insecure bool
 
+var recordFile, replayFile string
+var cassetteRecorder *api.Recorder
+var cassetteReplayer *api.Replayer
+
 var printVersion bool
 
 // rootCmd represents the base command when called without any subcommands
@@ -59,6 +66,16 @@ Common Management Commands:
   parsers <subcommand>
   views <subcommand>
 	status
+
+Exit Codes:
+  0  Success
+  1  General error
+  3  Not found
+  4  Permission denied, e.g. an invalid token
+  5  Conflict, e.g. the entity already exists
+  6  Validation error, the input was rejected
+  7  Rate limited
+  8  Server error
 		`,
 		Run: func(cmd *cobra.Command, args []string) {
 
@@ -91,15 +108,25 @@ Common Management Commands:
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "Config file (default is $HOME/.humio/config.yaml)")
 	rootCmd.PersistentFlags().StringVarP(&token, "token", "t", "", "The API token to use when talking to Humio. Overrides the value in your config file.")
 	rootCmd.PersistentFlags().StringVar(&tokenFile, "token-file", "", "File path to a file containing the API token. Overrides the value in your config file and the value of --token.")
+	rootCmd.PersistentFlags().StringVar(&tokenCommand, "token-command", "", "A command printing a JSON document with a short-lived API token and its expiry, e.g. {\"token\": \"...\", \"expiry\": \"2024-01-01T00:00:00Z\"}. Arguments are split and quoted as in a POSIX shell, without expanding variables. Overrides the value in your config file.")
 	rootCmd.PersistentFlags().StringVarP(&address, "address", "a", "", "The HTTP address of the Humio cluster. Overrides the value in your config file.")
 	rootCmd.PersistentFlags().StringVar(&caCertificateFile, "ca-certificate-file", "", "File path to a file containing the CA certificate in PEM format. Overrides the value in your config file.")
 	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "By default, all encrypted connections will verify that the hostname in the TLS certificate matches the name from the URL. Set this to true to ignore hostname validation.")
 	rootCmd.PersistentFlags().StringVar(&proxyOrganization, "proxy-organization", "", "Commands are executed in the specified organization.")
-	rootCmd.PersistentFlags().String("format", "", "Change output format of commands, if supported. Valid formats: json")
+	rootCmd.PersistentFlags().StringVar(&recordFile, "record", "", "Record all HTTP exchanges with the cluster to a file, with secrets scrubbed, so they can be replayed using --replay.")
+	rootCmd.PersistentFlags().StringVar(&replayFile, "replay", "", "Serve responses from a file created with --record instead of contacting the cluster.")
+	rootCmd.PersistentFlags().Int("concurrency", api.DefaultBulkConcurrency, "Maximum number of concurrent requests for commands covering many repositories or views, e.g. with --all-views.")
+	rootCmd.PersistentFlags().Float64("rate-limit", 0, "Maximum number of repositories or views per second that commands covering many of them start processing. Each one can take several requests. Unlimited by default.")
+	rootCmd.PersistentFlags().String("format", "", "Change output format of commands, if supported. Valid formats: json, yaml, csv, tsv, ndjson, template=<go-template>, template-file=<path>, jsonpath=<template>, jq=<expression>")
+	rootCmd.PersistentFlags().StringSlice("columns", nil, "Comma-separated list of columns to include in the output, in the given order.")
+	rootCmd.PersistentFlags().String("sort-by", "", "Column to sort the output by. Prefix with \"-\" to sort in descending order.")
+	rootCmd.PersistentFlags().Bool("no-headers", false, "Leave out the header of text and CSV output.")
+	rootCmd.PersistentFlags().StringArray("filter", nil, "Only include rows where a column matches a regular expression, given as <column>~<regex>, or <column>!~<regex> to exclude them. Can be repeated.")
 
 	_ = viper.BindPFlag(viperkey.Address, rootCmd.PersistentFlags().Lookup("address"))
 	_ = viper.BindPFlag(viperkey.Token, rootCmd.PersistentFlags().Lookup("token"))
 	_ = viper.BindPFlag(viperkey.TokenFile, rootCmd.PersistentFlags().Lookup("token-file"))
+	_ = viper.BindPFlag(viperkey.TokenCommand, rootCmd.PersistentFlags().Lookup("token-command"))
 	_ = viper.BindPFlag(viperkey.CACertificateFile, rootCmd.PersistentFlags().Lookup("ca-certificate-file"))
 	_ = viper.BindPFlag(viperkey.Insecure, rootCmd.PersistentFlags().Lookup("insecure"))
 	_ = viper.BindPFlag(viperkey.ProxyOrganization, rootCmd.PersistentFlags().Lookup("proxy-organization"))
@@ -112,6 +139,7 @@ Common Management Commands:
 	rootCmd.AddCommand(newProfilesCmd())
 	rootCmd.AddCommand(newIngestTokensCmd())
 	rootCmd.AddCommand(newViewsCmd())
+	rootCmd.AddCommand(newSearchDomainsCmd())
 	rootCmd.AddCommand(newCompletionCmd())
 	rootCmd.AddCommand(newLicenseCmd())
 	rootCmd.AddCommand(newReposCmd())
@@ -126,9 +154,15 @@ Common Management Commands:
 	rootCmd.AddCommand(newAggregateAlertsCmd())
 	rootCmd.AddCommand(newPackagesCmd())
 	rootCmd.AddCommand(newGroupsCmd())
+	rootCmd.AddCommand(newRolesCmd())
+	rootCmd.AddCommand(newAccessCmd())
 	rootCmd.AddCommand(newFilesCmd())
 	rootCmd.AddCommand(newFeatureFlagsCmd())
 	rootCmd.AddCommand(newTokensCmd())
+	rootCmd.AddCommand(newDashboardsCmd())
+	rootCmd.AddCommand(newSavedQueriesCmd())
+	rootCmd.AddCommand(newQueryBlocklistCmd())
+	rootCmd.AddCommand(newQueryQuotasCmd())
 
 	// Hidden Commands
 	rootCmd.AddCommand(newWelcomeCmd())
@@ -171,8 +205,9 @@ func initConfig() {
 		if address == "" {
 			viper.Set(viperkey.Address, profile.address)
 		}
-		if token == "" {
+		if token == "" && tokenCommand == "" {
 			viper.Set(viperkey.Token, profile.token)
+			viper.Set(viperkey.TokenCommand, profile.tokenCommand)
 		}
 		if caCertificateFile == "" {
 			viper.Set(viperkey.CACertificate, profile.caCertificate)
@@ -189,6 +224,11 @@ func initConfig() {
 		viper.Set(viperkey.Token, string(tokenFileContent))
 	}
 
+	// An explicitly passed token takes precedence over a configured credential command.
+	if token != "" || tokenFile != "" {
+		viper.Set(viperkey.TokenCommand, "")
+	}
+
 	if caCertificateFile != "" {
 		// #nosec G304
 		caCertificateFileContent, err := os.ReadFile(caCertificateFile)
@@ -199,6 +239,19 @@ func initConfig() {
 	if insecure {
 		viper.Set(viperkey.Insecure, insecure)
 	}
+
+	cassetteRecorder, cassetteReplayer = nil, nil
+	if recordFile != "" && replayFile != "" {
+		exitOnError(rootCmd, fmt.Errorf("--record and --replay cannot be used together"), "Invalid flags")
+	}
+	if recordFile != "" {
+		cassetteRecorder = api.NewRecorder(recordFile)
+	}
+	if replayFile != "" {
+		var err error
+		cassetteReplayer, err = api.LoadReplayer(replayFile)
+		exitOnError(rootCmd, err, "Error loading replay file")
+	}
 }
 
 func NewApiClient(cmd *cobra.Command, opts ...func(config *api.Config)) *api.Client {
@@ -222,6 +275,24 @@ func newApiClientE(opts ...func(config *api.Config)) (*api.Client, error) {
 	config.ProxyOrganization = viper.GetString(viperkey.ProxyOrganization)
 	config.UserAgent = fmt.Sprintf("humioctl/%s (%s on %s)", version, commit, date)
 
+	switch {
+	case cassetteRecorder != nil:
+		config.WrapTransport = cassetteRecorder.Transport
+	case cassetteReplayer != nil:
+		config.WrapTransport = func(http.RoundTripper) http.RoundTripper { return cassetteReplayer }
+	}
+
+	if command := viper.GetString(viperkey.TokenCommand); command != "" && cassetteReplayer == nil {
+		args, err := api.SplitCommandLine(command)
+		if err != nil {
+			return nil, err
+		}
+		config.TokenProvider, err = api.NewExecTokenProvider(args, tokenCacheFile(addr, command))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for _, opt := range opts {
 		opt(&config)
 	}
@@ -229,6 +300,16 @@ func newApiClientE(opts ...func(config *api.Config)) (*api.Client, error) {
 	return api.NewClient(config), nil
 }
 
+// tokenCacheFile returns the file used for caching tokens obtained from the credential command,
+// keyed by the cluster address and the command so profiles do not share tokens.
+func tokenCacheFile(addr, command string) string {
+	if cfgFile == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(addr + "\n" + command))
+	return path.Join(path.Dir(cfgFile), "token-cache", hex.EncodeToString(sum[:])+".json")
+}
+
 func main() {
 	SetVersion(version, commit, date)
 	err := rootCmd.Execute()
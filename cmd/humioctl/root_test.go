@@ -0,0 +1,1416 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/fakelogscale"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// runCommand executes humioctl with the given arguments against the fake server and returns the output.
+func runCommand(t *testing.T, server *fakelogscale.Server, args ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	executeCommand(t, server, &out, &out, args...)
+	return out.String()
+}
+
+// runCommandSeparately executes humioctl like runCommand, but returns its standard output and error separately.
+func runCommandSeparately(t *testing.T, server *fakelogscale.Server, args ...string) (string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	executeCommand(t, server, &stdout, &stderr, args...)
+	return stdout.String(), stderr.String()
+}
+
+func executeCommand(t *testing.T, server *fakelogscale.Server, stdout, stderr *bytes.Buffer, args ...string) {
+	t.Helper()
+
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(stderr)
+	rootCmd.SetArgs(append([]string{
+		"--config", filepath.Join(t.TempDir(), "config.yaml"),
+		"--address", server.URL,
+	}, args...))
+	defer resetFlags(rootCmd)
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("humioctl %s: %v\n%s%s", strings.Join(args, " "), err, stdout.String(), stderr.String())
+	}
+}
+
+// runFailingCommand executes humioctl in a child process, as failing commands exit the process,
+// and returns the output and exit code.
+func runFailingCommand(t *testing.T, server *fakelogscale.Server, args ...string) (string, int) {
+	t.Helper()
+
+	cmd := helperCommand(t, server, args...)
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("humioctl %s: %v", strings.Join(args, " "), err)
+	}
+	return string(out), cmd.ProcessState.ExitCode()
+}
+
+// helperCommand returns a command running humioctl with the given arguments in a child process.
+func helperCommand(t *testing.T, server *fakelogscale.Server, args ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$")
+	cmd.Env = append(os.Environ(), "HUMIOCTL_TEST_ARGS="+strings.Join(append([]string{
+		"--config", filepath.Join(t.TempDir(), "config.yaml"),
+		"--address", server.URL,
+	}, args...), "\n"))
+	return cmd
+}
+
+// TestHelperProcess runs humioctl with the arguments given by helperCommand.
+func TestHelperProcess(t *testing.T) {
+	args := os.Getenv("HUMIOCTL_TEST_ARGS")
+	if args == "" {
+		return
+	}
+	rootCmd.SetArgs(strings.Split(args, "\n"))
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// resetFlags restores flags changed by a previous run, as commands are only created once per process.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.PersistentFlags().VisitAll(reset)
+	cmd.Flags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
+func TestReposCommands(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+
+	out := runCommand(t, server, "repos", "create", "logs")
+	if !strings.Contains(out, "Successfully created repo logs") {
+		t.Errorf("unexpected output from repos create: %q", out)
+	}
+
+	out = runCommand(t, server, "repos", "list", "--format", "json")
+	if !strings.Contains(out, `"Name": "logs"`) {
+		t.Errorf("expected repository in output from repos list: %q", out)
+	}
+
+	out = runCommand(t, server, "repos", "list", "--format", "jsonpath={.rows[*].Name}")
+	if out != "logs\n" {
+		t.Errorf("unexpected output from repos list with jsonpath: %q", out)
+	}
+}
+
+func TestReposRename(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	server.AddView("all", "logs")
+
+	dir := t.TempDir()
+	for name, query := range map[string]string{"errors": "#repo=logs error", "weblogs": "#repo=weblogs error"} {
+		alertFile := filepath.Join(dir, name+".yaml")
+		writeFile(t, alertFile, `name: `+name+`
+queryString: "`+query+`"
+queryStart: 1h
+actions: []
+queryOwnershipType: Organization
+`)
+		runCommand(t, server, "alerts", "install", "all", "--file", alertFile)
+	}
+
+	stdout, stderr := runCommandSeparately(t, server, "repos", "rename", "logs", "app-logs")
+	if !strings.Contains(stdout, `Successfully renamed repository "logs" to "app-logs"`) {
+		t.Errorf("unexpected output from repos rename: %q", stdout)
+	}
+	if stderr != "Warning: alert \"errors\" in \"all\" references the old name \"logs\"\n" {
+		t.Errorf("expected a warning only about the alert mentioning the old name from repos rename: %q", stderr)
+	}
+
+	out := runCommand(t, server, "repos", "list", "--format", "jsonpath={[*].Name}")
+	if out != "app-logs\n" {
+		t.Errorf("expected the renamed repository in output from repos list: %q", out)
+	}
+}
+
+func TestActionsCommands(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	server.AddView("all", "logs")
+
+	actionFile := filepath.Join(t.TempDir(), "action.yaml")
+	writeFile(t, actionFile, `name: notify
+emailAction:
+  recipients:
+    - ops@example.com
+`)
+
+	out := runCommand(t, server, "actions", "install", "logs", "--file", actionFile)
+	if !strings.Contains(out, `Successfully installed action with name: "notify"`) {
+		t.Errorf("unexpected output from actions install: %q", out)
+	}
+
+	out = runCommand(t, server, "actions", "list", "logs")
+	if !strings.Contains(out, "notify") || !strings.Contains(out, "EmailAction") {
+		t.Errorf("expected action in output from actions list: %q", out)
+	}
+
+	out = runCommand(t, server, "actions", "list", "--all-views", "--format", "json")
+	if !strings.Contains(out, `"View": "logs"`) || strings.Contains(out, `"View": "all"`) {
+		t.Errorf("expected action of each view in output from actions list --all-views: %q", out)
+	}
+
+	out = runCommand(t, server, "actions", "test", "logs", "notify")
+	if !strings.Contains(out, `Successfully tested action "notify"`) {
+		t.Errorf("unexpected output from actions test: %q", out)
+	}
+
+	var testedType string
+	server.TestAction = func(typeName string, input map[string]interface{}) (bool, string) {
+		testedType = typeName
+		return true, "Action triggered"
+	}
+	runCommand(t, server, "actions", "test", "logs", "--file", actionFile)
+	if testedType != "EmailAction" {
+		t.Errorf("expected the action file to be tested as an email action, got %q", testedType)
+	}
+
+	out = runCommand(t, server, "actions", "remove", "logs", "notify")
+	if !strings.Contains(out, "Action removed") {
+		t.Errorf("unexpected output from actions remove: %q", out)
+	}
+}
+
+func TestActionsRender(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	eventFile := filepath.Join(dir, "event.json")
+	writeFile(t, eventFile, `{"status": 500, "message": "a \"quoted\" error"}`)
+
+	tests := []struct {
+		name     string
+		action   string
+		expected string
+	}{
+		{
+			name: "webhook",
+			action: `name: hook
+webhookAction:
+  method: PUT
+  url: https://example.com/{alert_name}
+  headers:
+    - header: X-Count
+      value: "{event_count}"
+    - header: X-Message
+      value: "{field:message}"
+  bodytemplate: '{"message": "{field:message}"}'
+`,
+			expected: `PUT https://example.com/Errors
+X-Count: 1
+X-Message: a "quoted" error
+
+{"message": "a \"quoted\" error"}
+`,
+		},
+		{
+			name: "slack",
+			action: `name: slack
+slackAction:
+  url: https://hooks.slack.com/services/T0/B0/X
+  fields:
+    - fieldname: Message
+      value: "{field:message}"
+`,
+			expected: `POST https://hooks.slack.com/services/T0/B0/X
+Content-Type: application/json
+
+{
+  "attachments": [
+    {
+      "fields": [
+        {
+          "short": false,
+          "title": "Message",
+          "value": "a \"quoted\" error"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			name: "slack post message",
+			action: `name: slack
+slackPostMessageAction:
+  apitoken: xoxb-token
+  channels:
+    - "#ops"
+    - "#dev"
+  fields:
+    - fieldname: Alert
+      value: "{alert_name}"
+`,
+			expected: `POST https://slack.com/api/chat.postMessage
+Content-Type: application/json
+Authorization: Bearer xoxb-token
+
+{
+  "attachments": [
+    {
+      "fields": [
+        {
+          "short": false,
+          "title": "Alert",
+          "value": "Errors"
+        }
+      ]
+    }
+  ],
+  "channel": "#ops"
+}
+
+POST https://slack.com/api/chat.postMessage
+Content-Type: application/json
+Authorization: Bearer xoxb-token
+
+{
+  "attachments": [
+    {
+      "fields": [
+        {
+          "short": false,
+          "title": "Alert",
+          "value": "Errors"
+        }
+      ]
+    }
+  ],
+  "channel": "#dev"
+}
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actionFile := filepath.Join(dir, "action.yaml")
+			writeFile(t, actionFile, test.action)
+
+			out := runCommand(t, server, "actions", "render", "--file", actionFile, "--event", eventFile, "--alert-name", "Errors")
+			if out != test.expected {
+				t.Errorf("expected output from actions render:\n%s\ngot:\n%s", test.expected, out)
+			}
+		})
+	}
+
+	for action, expected := range map[string][]string{
+		"opsGenieAction:\n  apiurl: https://api.opsgenie.com\n  geniekey: key\n": {
+			"POST https://api.opsgenie.com/v2/alerts\n", "Authorization: GenieKey key\n", `"message": "Errors"`,
+		},
+		"pagerDutyAction:\n  routingkey: key\n  severity: Critical\n": {
+			"POST https://events.pagerduty.com/v2/enqueue\n", `"routing_key": "key"`, `"severity": "critical"`, `"summary": "Errors"`,
+		},
+		"victorOpsAction:\n  notifyurl: https://alert.victorops.com/integrations\n  messagetype: CRITICAL\n": {
+			"POST https://alert.victorops.com/integrations\n", `"message_type": "CRITICAL"`, `"entity_display_name": "Errors"`,
+		},
+	} {
+		actionFile := filepath.Join(dir, "action.yaml")
+		writeFile(t, actionFile, "name: notify\n"+action)
+
+		out := runCommand(t, server, "actions", "render", "--file", actionFile, "--alert-name", "Errors")
+		for _, e := range expected {
+			if !strings.Contains(out, e) {
+				t.Errorf("expected %q in output from actions render: %q", e, out)
+			}
+		}
+	}
+}
+
+func TestActionSecrets(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	t.Setenv("GENIE_KEY", "secret-key")
+
+	dir := t.TempDir()
+	actionFile := filepath.Join(dir, "action.yaml")
+	writeFile(t, actionFile, `name: ops
+opsGenieAction:
+  apiurl: https://api.opsgenie.com
+  geniekey: ${GENIE_KEY}
+`)
+
+	out := runCommand(t, server, "actions", "install", "--check-secrets", "--file", actionFile)
+	if !strings.Contains(out, `Action "ops" contains no literal secrets`) {
+		t.Errorf("unexpected output from actions install --check-secrets: %q", out)
+	}
+
+	runCommand(t, server, "actions", "install", "logs", "--file", actionFile)
+
+	exportPath := filepath.Join(dir, "plain")
+	runCommand(t, server, "actions", "export", "logs", "ops", "--output", exportPath, "--secrets", "plain")
+	exported, err := os.ReadFile(exportPath + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(exported), "secret-key") {
+		t.Errorf("expected the secret to be resolved when installing:\n%s", exported)
+	}
+
+	exportPath = filepath.Join(dir, "exported")
+	runCommand(t, server, "actions", "export", "logs", "ops", "--output", exportPath)
+	exported, err = os.ReadFile(exportPath + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(exported), "${LOGS_OPS_GENIE_KEY}") || strings.Contains(string(exported), "secret-key") {
+		t.Errorf("expected the secret to be replaced when exporting:\n%s", exported)
+	}
+
+	// Actions with the same name in different views must not share secret files.
+	server.AddRepository("metrics")
+	t.Setenv("GENIE_KEY", "other-key")
+	runCommand(t, server, "actions", "install", "metrics", "--file", actionFile)
+
+	exportDir := filepath.Join(dir, "export-all")
+	secretsDir := filepath.Join(dir, "secrets")
+	runCommand(t, server, "actions", "export-all", "--all-views", "--outputDirectory", exportDir, "--secrets", "file", "--secrets-dir", secretsDir)
+	for view, key := range map[string]string{"logs": "secret-key", "metrics": "other-key"} {
+		exported, err := os.ReadFile(filepath.Join(exportDir, view, "ops.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		reference := "${file:" + filepath.Join("..", "..", "secrets", view, "ops_GenieKey") + "}"
+		if !strings.Contains(string(exported), reference) {
+			t.Errorf("expected a reference to the secret file of view %s relative to the action file:\n%s", view, exported)
+		}
+
+		// The references are resolved relative to the action file, not the working directory.
+		copyName := view + "-copy"
+		server.AddRepository(copyName)
+		runCommand(t, server, "actions", "install", copyName, "--file", filepath.Join(exportDir, view, "ops.yaml"))
+		exportPath := filepath.Join(dir, copyName)
+		runCommand(t, server, "actions", "export", copyName, "ops", "--output", exportPath, "--secrets", "plain")
+		exported, err = os.ReadFile(exportPath + ".yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(exported), "geniekey: "+key+"\n") {
+			t.Errorf("expected the secret exported from view %s to be installed:\n%s", view, exported)
+		}
+	}
+}
+
+func TestSearchCommand(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	if err := server.AddEvents("logs", map[string]interface{}{"@id": "1", "@timestamp": time.Now().UnixMilli(), "@rawstring": "hello world"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := runCommand(t, server, "search", "logs", "*", "--no-progress", "--fmt", "{@rawstring}")
+	if strings.TrimSpace(out) != "hello world" {
+		t.Errorf("unexpected output from search: %q", out)
+	}
+}
+
+func TestDashboardsCommands(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	dashboardFile := filepath.Join(t.TempDir(), "dashboard.yaml")
+	writeFile(t, dashboardFile, `name: Overview
+description: Errors by host
+labels:
+  - ops
+widgets:
+  errors:
+    queryString: error | count()
+parameters:
+  host:
+    type: text
+`)
+
+	out := runCommand(t, server, "dashboards", "install", "logs", "--file", dashboardFile)
+	if !strings.Contains(out, `Successfully installed dashboard "Overview" in view "logs"`) {
+		t.Errorf("unexpected output from dashboards install: %q", out)
+	}
+
+	out = runCommand(t, server, "dashboards", "list", "logs", "--format", "json")
+	if !strings.Contains(out, `"Name": "Overview"`) || !strings.Contains(out, `"Labels": "ops"`) || !strings.Contains(out, `"Widgets": 1`) {
+		t.Errorf("expected the dashboard in output from dashboards list: %q", out)
+	}
+
+	out = runCommand(t, server, "dashboards", "show", "logs", "Overview", "--format", "json")
+	if !strings.Contains(out, `"Description": "Errors by host"`) || !strings.Contains(out, `"Parameters": 1`) {
+		t.Errorf("unexpected output from dashboards show: %q", out)
+	}
+
+	exported := filepath.Join(t.TempDir(), "exported")
+	runCommand(t, server, "dashboards", "export", "logs", "Overview", "--output", exported)
+	content, err := os.ReadFile(exported + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "queryString: error | count()") {
+		t.Errorf("expected the widgets in the exported dashboard:\n%s", content)
+	}
+
+	out, code := runFailingCommand(t, server, "dashboards", "install", "logs", "--file", dashboardFile)
+	if code == 0 || !strings.Contains(out, `dashboard "Overview" already exists`) {
+		t.Errorf("expected dashboards install to refuse replacing the dashboard, got exit code %d: %q", code, out)
+	}
+
+	runCommand(t, server, "dashboards", "install", "logs", "--file", exported+".yaml", "--force")
+	out = runCommand(t, server, "dashboards", "list", "logs", "--format", "json")
+	if strings.Count(out, `"Name": "Overview"`) != 1 {
+		t.Errorf("expected dashboards install --force to replace the dashboard: %q", out)
+	}
+
+	out = runCommand(t, server, "dashboards", "remove", "logs", "Overview")
+	if !strings.Contains(out, `Successfully removed dashboard "Overview" from view "logs"`) {
+		t.Errorf("unexpected output from dashboards remove: %q", out)
+	}
+
+	// Dashboard names are not unique, but the commands address dashboards by name.
+	address, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := api.NewClient(api.Config{Address: address})
+	for i := 0; i < 2; i++ {
+		if _, err := client.Dashboards().Create("logs", "Overview", string(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"dashboards", "install", "logs", "--file", dashboardFile, "--force"},
+		{"dashboards", "remove", "logs", "Overview"},
+	} {
+		out, code = runFailingCommand(t, server, args...)
+		if code != exitCodeValidation || !strings.Contains(out, `several dashboards in "logs" are named "Overview"`) {
+			t.Errorf("expected %v to fail as the name is ambiguous, got exit code %d: %q", args, code, out)
+		}
+	}
+	out = runCommand(t, server, "dashboards", "list", "logs", "--format", "json")
+	if strings.Count(out, `"Name": "Overview"`) != 2 {
+		t.Errorf("expected no dashboard to be removed when the name is ambiguous: %q", out)
+	}
+}
+
+func TestSavedQueriesCommands(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	if err := server.AddEvents("logs", map[string]interface{}{"@id": "1", "@timestamp": time.Now().UnixMilli(), "@rawstring": "hello world"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var queryStrings []string
+	server.Query = func(queryString string, events []map[string]interface{}) ([]map[string]interface{}, bool) {
+		queryStrings = append(queryStrings, queryString)
+		return events, false
+	}
+
+	savedQueryFile := filepath.Join(t.TempDir(), "saved-query.yaml")
+	writeFile(t, savedQueryFile, `name: greetings
+queryString: hello
+start: 1h
+options:
+  columns:
+    - "@rawstring"
+`)
+
+	out := runCommand(t, server, "saved-queries", "install", "logs", "--file", savedQueryFile)
+	if !strings.Contains(out, `Successfully installed saved query "greetings"`) {
+		t.Errorf("unexpected output from saved-queries install: %q", out)
+	}
+
+	out = runCommand(t, server, "saved-queries", "list", "logs", "--format", "json")
+	if !strings.Contains(out, `"Query String": "hello"`) || !strings.Contains(out, `"Start": "1h"`) {
+		t.Errorf("expected saved query in output from saved-queries list: %q", out)
+	}
+
+	out = runCommand(t, server, "search", "logs", "--saved", "greetings", "--no-progress", "--fmt", "{@rawstring}")
+	if strings.TrimSpace(out) != "hello world" || len(queryStrings) != 1 || queryStrings[0] != "hello" {
+		t.Errorf("unexpected output from search --saved: %q, queries: %q", out, queryStrings)
+	}
+
+	if err := server.SetSavedQueryDescription("logs", "greetings", "Greets the world"); err != nil {
+		t.Fatal(err)
+	}
+	exported := filepath.Join(t.TempDir(), "exported")
+	runCommand(t, server, "saved-queries", "export", "logs", "greetings", "--output", exported)
+	content, err := os.ReadFile(exported + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "description: Greets the world\n") {
+		t.Errorf("expected the description in the exported saved query:\n%s", content)
+	}
+
+	_, stderr := runCommandSeparately(t, server, "saved-queries", "install", "logs", "--file", exported+".yaml", "--name", "copy")
+	if !strings.Contains(stderr, `Warning: the description of saved query "copy" was not installed`) {
+		t.Errorf("expected a warning about the description from saved-queries install: %q", stderr)
+	}
+
+	out = runCommand(t, server, "saved-queries", "remove", "logs", "greetings")
+	if !strings.Contains(out, `Successfully removed saved query "greetings"`) {
+		t.Errorf("unexpected output from saved-queries remove: %q", out)
+	}
+}
+
+func TestRolesCreate(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+
+	roleFile := filepath.Join(t.TempDir(), "role.yaml")
+	writeFile(t, roleFile, `name: Log readers
+description: Can search all logs
+viewPermissions:
+  - ReadAccess
+`)
+
+	out := runCommand(t, server, "roles", "create", "--file", roleFile)
+	if !strings.Contains(out, `Successfully created role "Log readers"`) {
+		t.Errorf("unexpected output from roles create: %q", out)
+	}
+
+	out = runCommand(t, server, "roles", "show", "Log readers", "--format", "json")
+	if !strings.Contains(out, `"Description": "Can search all logs"`) || !strings.Contains(out, `"View Permissions": "ReadAccess"`) {
+		t.Errorf("expected the description and permissions in output from roles show: %q", out)
+	}
+}
+
+func TestQueryBlocklistExportAndInstall(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	runCommand(t, server, "query-blocklist", "add", "* | groupBy(@rawstring)")
+	runCommand(t, server, "query-blocklist", "add", "^count\\(\\)$", "--type", "regex", "--view", "logs")
+	runCommand(t, server, "query-blocklist", "add", "head(1000000)", "--cluster-wide")
+
+	exported := filepath.Join(t.TempDir(), "blocklist")
+	runCommand(t, server, "query-blocklist", "export", "--cluster-wide", "--output", exported)
+
+	var blockedQueries []api.BlockedQuery
+	content, err := os.ReadFile(exported + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.UnmarshalStrict(content, &blockedQueries); err != nil || len(blockedQueries) != 3 {
+		t.Fatalf("expected three patterns in the exported blocklist, got %v:\n%s", err, content)
+	}
+
+	// Installing into another cluster adds all patterns, installing again adds none.
+	target := fakelogscale.NewServer()
+	defer target.Close()
+	target.AddRepository("logs")
+	runCommand(t, target, "query-blocklist", "add", "* | groupBy(@rawstring)")
+
+	out := runCommand(t, target, "query-blocklist", "install", "--file", exported+".yaml")
+	if !strings.Contains(out, "Successfully added 2 of 3 patterns to the query blocklist") {
+		t.Errorf("unexpected output from query-blocklist install: %q", out)
+	}
+	out = runCommand(t, target, "query-blocklist", "install", "--file", exported+".yaml")
+	if !strings.Contains(out, "Successfully added 0 of 3 patterns to the query blocklist") {
+		t.Errorf("unexpected output from installing the query blocklist again: %q", out)
+	}
+
+	out = runCommand(t, target, "query-blocklist", "list", "--cluster-wide", "--format", "json")
+	for _, expected := range []string{
+		`"Pattern": "* | groupBy(@rawstring)"`,
+		`"Pattern": "^count\\(\\)$"`,
+		`"View": "logs"`,
+		`"Type": "REGEX"`,
+		`"Scope": "cluster"`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %s in output from query-blocklist list: %q", expected, out)
+		}
+	}
+}
+
+func TestQueryQuotasExportAndInstall(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+
+	runCommand(t, server, "query-quotas", "set", "--default", "--interval", "PerHour", "--measurement", "QueryCount", "--limit", "1000")
+	runCommand(t, server, "query-quotas", "set", "alice@example.com", "--interval", "PerDay", "--measurement", "StaticCost", "--unlimited")
+	runCommand(t, server, "query-quotas", "set", "bob@example.com", "--interval", "PerMinute", "--measurement", "QueryCount", "--limit", "5")
+
+	exported := filepath.Join(t.TempDir(), "quotas")
+	runCommand(t, server, "query-quotas", "export", "--output", exported)
+
+	target := fakelogscale.NewServer()
+	defer target.Close()
+	runCommand(t, target, "query-quotas", "set", "bob@example.com", "--interval", "PerMinute", "--measurement", "QueryCount", "--limit", "50")
+
+	out := runCommand(t, target, "query-quotas", "install", "--file", exported+".yaml")
+	if !strings.Contains(out, "Successfully installed query quotas for 2 users") {
+		t.Errorf("unexpected output from query-quotas install: %q", out)
+	}
+
+	expected := runCommand(t, server, "query-quotas", "list", "--format", "csv")
+	actual := runCommand(t, target, "query-quotas", "list", "--format", "csv")
+	if actual != expected {
+		t.Errorf("expected the installed quotas to match the exported ones:\n%s\ngot:\n%s", expected, actual)
+	}
+	if !strings.Contains(actual, "alice@example.com,PerDay,StaticCost,unlimited\n") || !strings.Contains(actual, "bob@example.com,PerMinute,QueryCount,5\n") {
+		t.Errorf("unexpected output from query-quotas list: %q", actual)
+	}
+}
+
+func TestGroupsSync(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	for _, username := range []string{"alice", "bob", "carol"} {
+		server.AddUser(username, false)
+	}
+	for group, members := range map[string][]string{"developers": {"alice", "bob"}, "operators": {"carol"}, "admins": {"alice"}} {
+		if err := server.AddGroup(group, members...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	membersFile := filepath.Join(t.TempDir(), "members.yaml")
+	writeFile(t, membersFile, `developers:
+  - alice
+  - carol
+operators: []
+`)
+	members := func(group string) string {
+		out := runCommand(t, server, "groups", "show", group, "--format", "json")
+		var details struct{ Members string }
+		if err := json.Unmarshal([]byte(out), &details); err != nil {
+			t.Fatalf("unexpected output from groups show: %q", out)
+		}
+		return details.Members
+	}
+
+	out := runCommand(t, server, "groups", "sync", "--file", membersFile, "--dry-run")
+	expected := `Would add user "carol" to group "developers"
+Would remove user "bob" from group "developers"
+Would remove user "carol" from group "operators"
+`
+	if out != expected {
+		t.Errorf("expected output from groups sync --dry-run:\n%s\ngot:\n%s", expected, out)
+	}
+	if developers, operators := members("developers"), members("operators"); developers != "alice, bob" || operators != "carol" {
+		t.Errorf("expected groups sync --dry-run to change nothing, got developers %q and operators %q", developers, operators)
+	}
+
+	out = runCommand(t, server, "groups", "sync", "--file", membersFile)
+	expected = `Added user "carol" to group "developers"
+Removed user "bob" from group "developers"
+Removed user "carol" from group "operators"
+Successfully synchronized 2 groups
+`
+	if out != expected {
+		t.Errorf("expected output from groups sync:\n%s\ngot:\n%s", expected, out)
+	}
+	if developers, operators, admins := members("developers"), members("operators"), members("admins"); developers != "alice, carol" || operators != "" || admins != "alice" {
+		t.Errorf("unexpected members after groups sync: developers %q, operators %q, admins %q", developers, operators, admins)
+	}
+
+	writeFile(t, membersFile, "developers: [alice, mallory]\n")
+	out, code := runFailingCommand(t, server, "groups", "sync", "--file", membersFile)
+	if code != exitCodeNotFound || !strings.Contains(out, "mallory") {
+		t.Errorf("expected groups sync with an unknown user to exit with %d, got %d: %q", exitCodeNotFound, code, out)
+	}
+	if developers := members("developers"); developers != "alice, carol" {
+		t.Errorf("expected a failed groups sync to change nothing, got developers %q", developers)
+	}
+}
+
+func TestAccessReport(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	server.AddView("all-logs", "logs")
+	server.AddUser("admin", true)
+	server.AddUser("alice", false)
+	server.AddUser("bob", false)
+	if err := server.AddGroup("developers", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "reader.yaml"), "name: Reader\nviewPermissions:\n  - ReadAccess\n")
+	writeFile(t, filepath.Join(dir, "auditor.yaml"), "name: Auditor\norganizationPermissions:\n  - ViewUsage\n")
+	runCommand(t, server, "roles", "create", "--file", filepath.Join(dir, "reader.yaml"))
+	runCommand(t, server, "roles", "create", "--file", filepath.Join(dir, "auditor.yaml"))
+	runCommand(t, server, "roles", "assign", "Reader", "developers", "--view", "all-logs")
+	runCommand(t, server, "roles", "assign", "Auditor", "developers", "--organization")
+	if err := server.AssignUserRole("bob", "logs", "Reader"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := runCommand(t, server, "access", "report", "--format", "csv")
+	expected := `User,View,Permissions,Granted By
+admin,all-logs,*,root user
+admin,logs,*,root user
+alice,(organization),ViewUsage,"via group developers, role Auditor"
+alice,all-logs,ReadAccess,"via group developers, role Reader"
+alice,logs,ReadAccess,"via view all-logs, group developers, role Reader"
+bob,logs,ReadAccess,"direct, role Reader"
+`
+	if out != expected {
+		t.Errorf("unexpected output from access report:\n%s", out)
+	}
+
+	out = runCommand(t, server, "access", "report", "--matrix", "--format", "csv")
+	expected = `User,(organization),all-logs,logs
+admin,,*,*
+alice,ViewUsage,ReadAccess,ReadAccess
+bob,,,ReadAccess
+`
+	if out != expected {
+		t.Errorf("unexpected output from access report --matrix:\n%s", out)
+	}
+}
+
+func TestTokensCommands(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	out := runCommand(t, server, "tokens", "create", "ci-reader", "--type", "view", "--view", "logs", "--permission", "ReadAccess")
+	prefix := `Successfully created token "ci-reader": `
+	if !strings.HasPrefix(out, prefix) {
+		t.Fatalf("unexpected output from tokens create: %q", out)
+	}
+	secret := strings.TrimSpace(strings.TrimPrefix(out, prefix))
+
+	out = runCommand(t, server, "tokens", "show", "ci-reader", "--format", "json")
+	if !strings.Contains(out, `"Views": "logs"`) || !strings.Contains(out, `"Permissions": "ReadAccess"`) || strings.Contains(out, secret) {
+		t.Errorf("unexpected output from tokens show: %q", out)
+	}
+
+	// An existing secret file must not stay readable by others when the secret is written to it.
+	secretFile := filepath.Join(t.TempDir(), "deployer.token")
+	writeFile(t, secretFile, "old secret\n")
+	if err := os.Chmod(secretFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out = runCommand(t, server, "tokens", "create", "deployer", "--type", "organization", "--permission", "ManageUsers", "--secret-file", secretFile)
+	if out != `Successfully created token "deployer" and wrote it to `+secretFile+"\n" {
+		t.Errorf("unexpected output from tokens create --secret-file: %q", out)
+	}
+	written := readSecretFile(t, secretFile)
+
+	out = runCommand(t, server, "tokens", "rotate", "deployer", "--secret-file", secretFile)
+	if out != `Successfully rotated token "deployer" and wrote it to `+secretFile+"\n" {
+		t.Errorf("unexpected output from tokens rotate --secret-file: %q", out)
+	}
+	if rotated := readSecretFile(t, secretFile); rotated == written {
+		t.Errorf("expected tokens rotate to write a new secret, got %q again", rotated)
+	}
+
+	out = runCommand(t, server, "tokens", "rotate", "ci-reader")
+	if !strings.HasPrefix(out, `Successfully rotated token "ci-reader": `) || strings.Count(out, "~") != 1 || strings.Contains(out, secret) {
+		t.Errorf("expected the new secret once in output from tokens rotate: %q", out)
+	}
+
+	out = runCommand(t, server, "tokens", "delete", "ci-reader")
+	if !strings.Contains(out, `Successfully deleted token "ci-reader"`) {
+		t.Errorf("unexpected output from tokens delete: %q", out)
+	}
+	out = runCommand(t, server, "tokens", "list", "--no-headers")
+	if strings.Contains(out, "ci-reader") || !strings.Contains(out, "deployer") {
+		t.Errorf("expected only the remaining token in output from tokens list: %q", out)
+	}
+}
+
+func TestTokensListPaging(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	for i := 0; i < 250; i++ {
+		server.AddToken(fmt.Sprintf("token-%03d", i), "ManageUsers")
+	}
+
+	out := runCommand(t, server, "tokens", "list", "--type", "organization", "--columns", "Name", "--no-headers")
+	names := strings.Fields(out)
+	if len(names) != 250 || names[0] != "token-000" || names[249] != "token-249" {
+		t.Errorf("expected all 250 tokens from tokens list, got %d: %q", len(names), out)
+	}
+}
+
+// readSecretFile returns the secret in a file written by humioctl, which must only be readable by the current user.
+func readSecretFile(t *testing.T, path string) string {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("expected secret file mode 0600, got %o", mode)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "~") {
+		t.Errorf("expected a token secret in %s, got %q", path, content)
+	}
+	return string(content)
+}
+
+func TestFilterAlertsAllViews(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	server.AddRepository("metrics")
+
+	filterAlertFile := filepath.Join(t.TempDir(), "filter-alert.yaml")
+	writeFile(t, filterAlertFile, `name: errors
+queryString: error
+actionNames: []
+labels: []
+enabled: true
+throttleTimeSeconds: 60
+queryOwnershipType: Organization
+`)
+	runCommand(t, server, "filter-alerts", "install", "logs", "--file", filterAlertFile)
+	runCommand(t, server, "filter-alerts", "install", "metrics", "--file", filterAlertFile)
+
+	out := runCommand(t, server, "filter-alerts", "list", "--all-views", "--format", "json")
+	if !strings.Contains(out, `"View": "logs"`) || !strings.Contains(out, `"View": "metrics"`) {
+		t.Errorf("expected the filter alert of each view in output from filter-alerts list --all-views: %q", out)
+	}
+
+	dir := t.TempDir()
+	runCommand(t, server, "filter-alerts", "export-all", "--all-views", "--outputDirectory", dir)
+	for _, view := range []string{"logs", "metrics"} {
+		content, err := os.ReadFile(filepath.Join(dir, view, "errors.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), "queryString: error\n") {
+			t.Errorf("unexpected filter alert exported from %s:\n%s", view, content)
+		}
+	}
+}
+
+func TestAlertsRemove(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	alertFile := filepath.Join(t.TempDir(), "alert.yaml")
+	writeFile(t, alertFile, `name: errors
+queryString: error
+queryStart: 1h
+actions: []
+queryOwnershipType: Organization
+`)
+	runCommand(t, server, "alerts", "install", "logs", "--file", alertFile)
+
+	// The alert must be deleted by its ID, which differs from its name.
+	out := runCommand(t, server, "alerts", "remove", "logs", "errors")
+	if !strings.Contains(out, `Successfully removed alert "errors" from view "logs"`) {
+		t.Errorf("unexpected output from alerts remove: %q", out)
+	}
+
+	out = runCommand(t, server, "alerts", "list", "logs")
+	if strings.Contains(out, "errors") {
+		t.Errorf("expected removed alert to be gone from output from alerts list: %q", out)
+	}
+}
+
+func TestAlertsEnableDisableAndLabels(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	alertFile := filepath.Join(t.TempDir(), "alert.yaml")
+	writeFile(t, alertFile, `name: errors
+queryString: error
+queryStart: 1h
+enabled: true
+actions: []
+queryOwnershipType: Organization
+labels:
+  - team-a
+`)
+	runCommand(t, server, "alerts", "install", "logs", "--file", alertFile)
+
+	out := runCommand(t, server, "alerts", "disable", "logs", "--label", "team-a", "--type", "alert")
+	if !strings.Contains(out, `Successfully disabled alert "errors" in "logs"`) {
+		t.Errorf("unexpected output from alerts disable: %q", out)
+	}
+
+	out = runCommand(t, server, "alerts", "disable", "logs", "--name", "errors", "--type", "alert")
+	if !strings.Contains(out, `is already disabled`) {
+		t.Errorf("unexpected output from alerts disable of a disabled alert: %q", out)
+	}
+
+	runCommand(t, server, "alerts", "labels", "add", "logs", "paging", "--name", "errors", "--type", "alert")
+	runCommand(t, server, "alerts", "labels", "remove", "logs", "team-a", "--name", "errors", "--type", "alert")
+	runCommand(t, server, "alerts", "enable", "logs", "--label", "paging", "--type", "alert")
+
+	out = runCommand(t, server, "alerts", "show", "logs", "errors", "--format", "json")
+	if !strings.Contains(out, `"Enabled": true`) || !strings.Contains(out, `"Labels": "paging"`) {
+		t.Errorf("expected enabled alert with changed labels in output from alerts show: %q", out)
+	}
+}
+
+func TestAlertsMaintenance(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	alertFile := filepath.Join(t.TempDir(), "alert.yaml")
+	writeFile(t, alertFile, `name: errors
+queryString: error
+queryStart: 1h
+enabled: true
+actions: []
+queryOwnershipType: Organization
+labels:
+  - team-a
+`)
+	runCommand(t, server, "alerts", "install", "logs", "--file", alertFile)
+
+	out := runCommand(t, server, "alerts", "maintenance", "logs", "--label", "team-a", "--type", "alert", "--for", "10ms")
+	if !strings.Contains(out, `Successfully disabled alert "errors" in "logs"`) || !strings.Contains(out, `Successfully enabled alert "errors" in "logs"`) {
+		t.Errorf("unexpected output from alerts maintenance: %q", out)
+	}
+
+	out = runCommand(t, server, "alerts", "show", "logs", "errors", "--format", "json")
+	if !strings.Contains(out, `"Enabled": true`) || !strings.Contains(out, `"Labels": "team-a"`) {
+		t.Errorf("expected the alert to be enabled again and its maintenance label removed after maintenance: %q", out)
+	}
+}
+
+func TestAlertsMaintenanceInterrupted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sending signals requires a POSIX system")
+	}
+
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	alertFile := filepath.Join(t.TempDir(), "alert.yaml")
+	writeFile(t, alertFile, `name: errors
+queryString: error
+queryStart: 1h
+enabled: true
+actions: []
+queryOwnershipType: Organization
+`)
+	runCommand(t, server, "alerts", "install", "logs", "--file", alertFile)
+
+	// startMaintenance starts a maintenance in a child process, and returns once the alert is disabled.
+	startMaintenance := func() (*exec.Cmd, string) {
+		cmd := helperCommand(t, server, "alerts", "maintenance", "logs", "--name", "errors", "--for", "1h")
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			if label, ok := strings.CutPrefix(scanner.Text(), "The disabled alerts are labelled "); ok {
+				go func() { _, _ = io.Copy(io.Discard, stdout) }()
+				return cmd, strings.Fields(label)[0]
+			}
+		}
+		t.Fatalf("alerts maintenance ended without labelling the alert: %v", cmd.Wait())
+		return nil, ""
+	}
+	show := func() string {
+		return runCommand(t, server, "alerts", "show", "logs", "errors", "--format", "json")
+	}
+
+	cmd, label := startMaintenance()
+	if out := show(); !strings.Contains(out, `"Enabled": false`) || !strings.Contains(out, strings.Trim(label, `"`)) {
+		t.Errorf("expected the alert to be disabled and labelled %s during maintenance: %q", label, out)
+	}
+	if err := cmd.Process.Signal(syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected alerts maintenance to end cleanly on SIGHUP: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		_ = cmd.Process.Kill()
+		t.Fatal("alerts maintenance did not end on SIGHUP")
+	}
+	if out := show(); !strings.Contains(out, `"Enabled": true`) || strings.Contains(out, "maintenance-until") {
+		t.Fatalf("expected the alert to be enabled and its label removed after SIGHUP: %q", out)
+	}
+
+	// A killed maintenance leaves the alert disabled, but it can be found by its label.
+	cmd, label = startMaintenance()
+	if err := cmd.Process.Kill(); err != nil {
+		t.Fatal(err)
+	}
+	_ = cmd.Wait()
+	if out := show(); !strings.Contains(out, `"Enabled": false`) {
+		t.Errorf("expected the alert to stay disabled after the maintenance was killed: %q", out)
+	}
+	out := runCommand(t, server, "alerts", "enable", "--all-views", "--label", strings.Trim(label, `"`), "--type", "alert")
+	if !strings.Contains(out, `Successfully enabled alert "errors" in "logs"`) {
+		t.Errorf("expected alerts enable --label to recover the alert: %q", out)
+	}
+}
+
+func TestAlertsHealth(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	alertFile := filepath.Join(t.TempDir(), "alert.yaml")
+	writeFile(t, alertFile, `name: errors
+queryString: error
+queryStart: 1h
+actions: []
+queryOwnershipType: Organization
+`)
+	runCommand(t, server, "alerts", "install", "logs", "--file", alertFile)
+	if err := server.SetAlertError("logs", "errors", "query timed out"); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr := runCommandSeparately(t, server, "alerts", "health", "logs", "--type", "alert", "--clear", "--format", "json")
+	var rows []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &rows); err != nil {
+		t.Errorf("expected only JSON on standard output from alerts health --clear: %v: %q", err, stdout)
+	}
+	if len(rows) != 1 || rows[0]["Last Error"] != "query timed out" {
+		t.Errorf("unexpected output from alerts health --clear: %q", stdout)
+	}
+	if !strings.Contains(stderr, `Successfully cleared error of alert "errors" in "logs"`) {
+		t.Errorf("expected cleared errors to be reported on standard error from alerts health --clear: %q", stderr)
+	}
+
+	out := runCommand(t, server, "alerts", "health", "logs", "--type", "alert", "--format", "json")
+	if strings.Contains(out, "query timed out") {
+		t.Errorf("expected cleared error to be gone from output from alerts health: %q", out)
+	}
+}
+
+func TestAlertsBacktest(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+	now := time.Now()
+	for i, age := range []time.Duration{150 * time.Minute, 90 * time.Minute, 5 * time.Hour} {
+		event := map[string]interface{}{"@id": fmt.Sprint(i), "@timestamp": now.Add(-age).UnixMilli(), "@rawstring": "error"}
+		if err := server.AddEvents("logs", event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	alertFile := filepath.Join(t.TempDir(), "alert.yaml")
+	writeFile(t, alertFile, `name: errors
+queryString: error
+searchIntervalSeconds: 3600
+throttleTimeSeconds: 7200
+`)
+
+	// The windows are the last three hours. The oldest event is outside of them, and the second trigger
+	// one hour after the first is throttled.
+	out := runCommand(t, server, "alerts", "backtest", "logs", "--file", alertFile, "--over", "3h", "--format", "json")
+	for _, expected := range []string{`"Windows": 3`, `"Windows With Results": 2`, `"Results": 2`, `"Triggers": 1`} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %s in output from alerts backtest: %q", expected, out)
+		}
+	}
+
+	scheduledSearchFile := filepath.Join(t.TempDir(), "scheduled-search.yaml")
+	writeFile(t, scheduledSearchFile, `name: errors
+queryString: error
+queryStart: 2h
+queryEnd: 1h
+schedule: "0 * * * *"
+`)
+	out, code := runFailingCommand(t, server, "alerts", "backtest", "logs", "--file", scheduledSearchFile, "--over", "3h")
+	if code != exitCodeValidation || !strings.Contains(out, "scheduled searches cannot be backtested") {
+		t.Errorf("expected alerts backtest of a scheduled search to fail with exit code %d, got %d: %q", exitCodeValidation, code, out)
+	}
+}
+
+func TestAlertsMigrate(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	dir := t.TempDir()
+	for name, queryString := range map[string]string{
+		"errors":   "error",
+		"warnings": "warning",
+		"slowest":  "slow | sort(duration)",
+		"broken":   "error | count(",
+	} {
+		alertFile := filepath.Join(dir, name+".yaml")
+		writeFile(t, alertFile, fmt.Sprintf(`name: %s
+queryString: %q
+queryStart: 1h
+throttleTimeMillis: 1500
+enabled: true
+actions: []
+queryOwnershipType: Organization
+labels: []
+`, name, queryString))
+		runCommand(t, server, "alerts", "install", "logs", "--file", alertFile)
+	}
+
+	filterAlertFile := filepath.Join(dir, "filter-alert.yaml")
+	writeFile(t, filterAlertFile, `name: warnings
+queryString: warning
+actionNames: []
+labels: []
+enabled: true
+throttleTimeSeconds: 60
+queryOwnershipType: Organization
+`)
+	runCommand(t, server, "filter-alerts", "install", "logs", "--file", filterAlertFile)
+
+	out, code := runFailingCommand(t, server, "alerts", "migrate", "logs", "--dry-run", "--format", "csv")
+	if code != exitCodeError {
+		t.Errorf("expected exit code %d from alerts migrate --dry-run with an invalid query, got %d: %q", exitCodeError, code, out)
+	}
+	for _, row := range []string{
+		`errors,filter-alert,would migrate,"throttle time of 1500ms rounded up to 2s; query start 1h is not used, filter alerts run on each event"` + "\n",
+		"warnings,filter-alert,skipped,a filter-alert with the same name already exists\n",
+		"slowest,,skipped,the query can only be used by a legacy alert\n",
+		"broken,,failed,invalid query: unbalanced parentheses\n",
+	} {
+		if !strings.Contains(out, row) {
+			t.Errorf("expected %q in output from alerts migrate --dry-run: %q", row, out)
+		}
+	}
+	out = runCommand(t, server, "filter-alerts", "list", "logs", "--format", "json")
+	if strings.Contains(out, `"errors"`) {
+		t.Errorf("expected no filter alert created by alerts migrate --dry-run: %q", out)
+	}
+
+	out = runCommand(t, server, "alerts", "migrate", "logs", "errors", "--disable-old", "--format", "csv")
+	if !strings.Contains(out, "errors,filter-alert,migrated,") || !strings.Contains(out, `legacy alert disabled"`) {
+		t.Errorf("unexpected output from alerts migrate --disable-old: %q", out)
+	}
+	out = runCommand(t, server, "alerts", "show", "logs", "errors", "--format", "json")
+	if !strings.Contains(out, `"Enabled": false`) {
+		t.Errorf("expected the legacy alert to be disabled by alerts migrate --disable-old: %q", out)
+	}
+	out = runCommand(t, server, "filter-alerts", "show", "logs", "errors", "--format", "json")
+	if !strings.Contains(out, `"Enabled": false`) || !strings.Contains(out, `"Throttle Time Seconds": 2`) {
+		t.Errorf("expected a disabled filter alert created by alerts migrate: %q", out)
+	}
+
+	out, code = runFailingCommand(t, server, "alerts", "migrate", "logs", "--format", "csv")
+	if code != exitCodeError {
+		t.Errorf("expected exit code %d from alerts migrate with an invalid query, got %d: %q", exitCodeError, code, out)
+	}
+	if !strings.Contains(out, "errors,filter-alert,skipped,a filter-alert with the same name already exists\n") {
+		t.Errorf("expected the migrated alert to be skipped when migrating again: %q", out)
+	}
+}
+
+func TestParsersTestCommand(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	parserFile := filepath.Join(t.TempDir(), "parser.yaml")
+	writeFile(t, parserFile, `name: accesslog
+script: kvParse()
+testCases:
+  - event:
+      rawString: status=200 method=GET
+    assertions:
+      - outputEventIndex: 0
+        fieldsHaveValues:
+          status: "200"
+          method: GET
+        fieldsNotPresent:
+          - error
+`)
+
+	out := runCommand(t, server, "parsers", "test", "logs", parserFile)
+	if !strings.Contains(out, "PASS  test case 1: status=200 method=GET") || !strings.Contains(out, "1 of 1 test cases passed") {
+		t.Errorf("unexpected output from parsers test: %q", out)
+	}
+
+	writeFile(t, parserFile, `name: accesslog
+script: kvParse()
+testCases:
+  - event:
+      rawString: status=200 method=GET
+    assertions:
+      - outputEventIndex: 0
+        fieldsHaveValues:
+          status: "200"
+  - event:
+      rawString: status=500 error=timeout
+    assertions:
+      - outputEventIndex: 0
+        fieldsHaveValues:
+          status: "200"
+        fieldsNotPresent:
+          - error
+`)
+
+	out, code := runFailingCommand(t, server, "parsers", "test", "logs", parserFile)
+	if code == 0 {
+		t.Errorf("expected parsers test to fail when a test case fails: %q", out)
+	}
+	for _, expected := range []string{
+		"PASS  test case 1: status=200 method=GET",
+		"FAIL  test case 2: status=500 error=timeout",
+		`output event 0, field "error": field is present, but was expected to be absent (value "timeout")`,
+		`output event 0, field "status": field has an unexpected value`,
+		`- expected: "200"`,
+		`+ actual:   "500"`,
+		"1 of 2 test cases passed",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in output from parsers test: %q", expected, out)
+		}
+	}
+}
+
+func TestParsersGenTests(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+	server.AddRepository("logs")
+
+	dir := t.TempDir()
+	parserFile := filepath.Join(dir, "parser.yaml")
+	writeFile(t, parserFile, `name: accesslog
+script: kvParse()
+tagFields: []
+fieldsToBeRemovedBeforeParsing: []
+testCases:
+  - event:
+      rawString: status=200
+    assertions:
+      - outputEventIndex: 0
+        fieldsHaveValues:
+          status: "200"
+`)
+	runCommand(t, server, "parsers", "install", "logs", "--file", parserFile)
+
+	samplesFile := filepath.Join(dir, "samples.log")
+	writeFile(t, samplesFile, "status=500 error=timeout\n")
+
+	output := filepath.Join(dir, "generated")
+	out := runCommand(t, server, "parsers", "gen-tests", "logs", "accesslog", "--samples", samplesFile, "--append", "--output", output)
+	if !strings.Contains(out, "Successfully wrote 1 test cases to "+output+".yaml") {
+		t.Errorf("unexpected output from parsers gen-tests: %q", out)
+	}
+
+	content, err := os.ReadFile(output + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parser api.Parser
+	if err := yaml.Unmarshal(content, &parser); err != nil {
+		t.Fatal(err)
+	}
+	if len(parser.TestCases) != 2 {
+		t.Fatalf("expected the existing and the generated test case, got:\n%s", content)
+	}
+	if value := parser.TestCases[0].Assertions[0].FieldsHaveValues["status"]; value != "200" {
+		t.Errorf("expected the existing assertion to keep its value, got %q in:\n%s", value, content)
+	}
+	if value := parser.TestCases[1].Assertions[0].FieldsHaveValues["error"]; value != "timeout" {
+		t.Errorf("expected the generated assertion on the sample, got %q in:\n%s", value, content)
+	}
+}
+
+func TestFeatureFlagsEnableUnsupported(t *testing.T) {
+	server := fakelogscale.NewServer()
+	defer server.Close()
+
+	out, code := runFailingCommand(t, server, "feature-flags", "enable", "--global", "NoSuchFlag")
+	if code == 0 {
+		t.Errorf("expected feature-flags enable to fail for an unsupported flag: %q", out)
+	}
+	if !strings.Contains(out, "Supported ones are:\n  - ExportToBucket\n  - RepeatingQueries\n") {
+		t.Errorf("expected the names of the supported flags in output from feature-flags enable: %q", out)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := fakelogscale.NewServer()
+	server.Token = "secret-token"
+	server.AddRepository("logs")
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	recorded := runCommand(t, server, "repos", "list", "--token", "secret-token", "--record", cassette)
+	server.Close()
+
+	content, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "secret-token") {
+		t.Errorf("expected token to be scrubbed from cassette: %s", content)
+	}
+
+	replayed := runCommand(t, server, "repos", "list", "--replay", cassette)
+	if replayed != recorded {
+		t.Errorf("expected replayed output %q, got %q", recorded, replayed)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newSavedQueriesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "saved-queries",
+		Short: "Manage saved queries",
+	}
+
+	cmd.AddCommand(newSavedQueriesListCmd())
+	cmd.AddCommand(newSavedQueriesShowCmd())
+	cmd.AddCommand(newSavedQueriesExportCmd())
+	cmd.AddCommand(newSavedQueriesExportAllCmd())
+	cmd.AddCommand(newSavedQueriesInstallCmd())
+	cmd.AddCommand(newSavedQueriesRemoveCmd())
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newSavedQueriesExportCmd() *cobra.Command {
+	var outputName string
+
+	cmd := cobra.Command{
+		Use:   "export [flags] <view> <saved-query>",
+		Short: "Export a saved query <saved-query> in <view> to a file.",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			savedQueryName := args[1]
+			client := NewApiClient(cmd)
+
+			if outputName == "" {
+				outputName = savedQueryName
+			}
+
+			savedQuery, err := client.SavedQueries().Get(view, savedQueryName)
+			exitOnError(cmd, err, "Error fetching saved query")
+
+			yamlData, err := yaml.Marshal(savedQuery)
+			exitOnError(cmd, err, "Failed to serialize the saved query")
+
+			outFilePath := outputName + ".yaml"
+			err = os.WriteFile(outFilePath, yamlData, 0600)
+			exitOnError(cmd, err, "Error saving the saved query file")
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputName, "output", "o", "", "The file path where the saved query should be written. Defaults to ./<saved-query-name>.yaml")
+
+	return &cmd
+}
+
+func newSavedQueriesExportAllCmd() *cobra.Command {
+	var outputDirectory string
+
+	cmd := cobra.Command{
+		Use:   "export-all <view>",
+		Short: "Export all saved queries",
+		Long:  `Export all saved queries to yaml files with naming <sanitized-saved-query-name>.yaml. All non-alphanumeric characters will be replaced with underscore.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			client := NewApiClient(cmd)
+
+			savedQueries, err := client.SavedQueries().List(view)
+			exitOnError(cmd, err, "Error fetching saved queries")
+
+			for i := range savedQueries {
+				yamlData, err := yaml.Marshal(&savedQueries[i])
+				exitOnError(cmd, err, "Failed to serialize the saved query")
+				savedQueryFilename := sanitizeTriggerName(savedQueries[i].Name) + ".yaml"
+
+				var outFilePath string
+				if outputDirectory != "" {
+					outFilePath = outputDirectory + "/" + savedQueryFilename
+				} else {
+					outFilePath = savedQueryFilename
+				}
+
+				err = os.WriteFile(outFilePath, yamlData, 0600)
+				exitOnError(cmd, err, "Error saving the saved query to file")
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputDirectory, "outputDirectory", "d", "", "The file path where the saved queries should be written. Defaults to current directory.")
+
+	return &cmd
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newSavedQueriesInstallCmd() *cobra.Command {
+	var filePath, url, name string
+
+	cmd := cobra.Command{
+		Use:   "install [flags] <view>",
+		Short: "Installs a saved query in a view",
+		Long: `Install a saved query from a URL or from a local file.
+
+The install command allows you to install saved queries from a URL or from a local file, e.g.
+
+  $ humioctl saved-queries install viewName --url=https://example.com/acme/saved-query.yaml
+
+  $ humioctl saved-queries install viewName --file=./saved-query.yaml
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var content []byte
+			var err error
+
+			if filePath != "" {
+				content, err = getBytesFromFile(filePath)
+			} else if url != "" {
+				content, err = getBytesFromURL(url)
+			} else {
+				cmd.Printf("You must specify a path using --file or --url\n")
+				os.Exit(1)
+			}
+			exitOnError(cmd, err, "Could not load the saved query")
+
+			client := NewApiClient(cmd)
+			viewName := args[0]
+
+			var savedQuery api.SavedQuery
+			err = yaml.Unmarshal(content, &savedQuery)
+			exitOnError(cmd, err, "Could not unmarshal the saved query")
+
+			if name != "" {
+				savedQuery.Name = name
+			}
+
+			_, err = client.SavedQueries().Create(viewName, &savedQuery)
+			exitOnError(cmd, err, "Could not create the saved query")
+
+			if savedQuery.Description != nil {
+				cmd.PrintErrf("Warning: the description of saved query %q was not installed, as it cannot be set through the API\n", savedQuery.Name)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully installed saved query %q in view %q\n", savedQuery.Name, viewName)
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "file", "", "The local file path to the saved query to install.")
+	cmd.Flags().StringVar(&url, "url", "", "A URL to fetch the saved query file from.")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Install the saved query under a specific name, ignoring the `name` attribute in the saved query file.")
+	cmd.MarkFlagsMutuallyExclusive("file", "url")
+
+	return &cmd
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newSavedQueriesListCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "list <view>",
+		Short: "List all saved queries in a view.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			client := NewApiClient(cmd)
+
+			savedQueries, err := client.SavedQueries().List(view)
+			exitOnError(cmd, err, "Error fetching saved queries")
+
+			var rows = make([][]format.Value, len(savedQueries))
+			for i := range savedQueries {
+				savedQuery := savedQueries[i]
+				rows[i] = []format.Value{
+					format.String(savedQuery.ID),
+					format.String(savedQuery.Name),
+					format.StringPtr(savedQuery.Description),
+					format.String(savedQuery.QueryString),
+					format.String(savedQuery.Start),
+					format.String(savedQuery.End),
+					format.Bool(savedQuery.IsLive),
+				}
+			}
+
+			printOverviewTable(cmd, []string{"ID", "Name", "Description", "Query String", "Start", "End", "Live"}, rows)
+		},
+	}
+
+	return &cmd
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newSavedQueriesRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <view> <name>",
+		Short: "Removes a saved query.",
+		Long:  `Removes the saved query with name '<name>' in the view with name '<view>'.`,
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			viewName := args[0]
+			savedQueryName := args[1]
+			client := NewApiClient(cmd)
+
+			savedQuery, err := client.SavedQueries().Get(viewName, savedQueryName)
+			exitOnError(cmd, err, "Error fetching saved query")
+
+			err = client.SavedQueries().Delete(viewName, savedQuery.ID)
+			exitOnError(cmd, err, "Could not remove saved query")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully removed saved query %q from view %q\n", savedQueryName, viewName)
+		},
+	}
+
+	return cmd
+}
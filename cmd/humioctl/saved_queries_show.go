@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newSavedQueriesShowCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "show <view> <name>",
+		Short: "Show details about a saved query in a view.",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			view := args[0]
+			name := args[1]
+			client := NewApiClient(cmd)
+
+			savedQuery, err := client.SavedQueries().Get(view, name)
+			exitOnError(cmd, err, "Error fetching saved query")
+
+			details := [][]format.Value{
+				{format.String("ID"), format.String(savedQuery.ID)},
+				{format.String("Name"), format.String(savedQuery.Name)},
+				{format.String("Description"), format.StringPtr(savedQuery.Description)},
+				{format.String("Query String"), format.String(savedQuery.QueryString)},
+				{format.String("Start"), format.String(savedQuery.Start)},
+				{format.String("End"), format.String(savedQuery.End)},
+				{format.String("Live"), format.Bool(savedQuery.IsLive)},
+				{format.String("Widget Type"), format.String(savedQuery.WidgetType)},
+			}
+
+			printDetailsTable(cmd, details)
+		},
+	}
+
+	return &cmd
+}
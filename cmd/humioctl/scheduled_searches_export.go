@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/humio/cli/internal/api"
@@ -68,39 +69,43 @@ func newScheduledSearchesExportCmd() *cobra.Command {
 
 func newScheduledSearchesExportAllCmd() *cobra.Command {
 	var outputDirectory string
+	var allViews bool
 
 	cmd := cobra.Command{
-		Use:   "export-all <view>",
+		Use:   "export-all (<view> | --all-views)",
 		Short: "Export all scheduled searches",
-		Long:  `Export all scheduled searches to yaml files with naming <sanitized-scheduled-search-name>.yaml.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Export all scheduled searches to yaml files with naming <sanitized-scheduled-search-name>.yaml.
+
+With --all-views, the scheduled searches of each repository and view are written to a subdirectory named <sanitized-view-name>.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			searchDomains := searchDomainsFromArgs(cmd, client, args, allViews)
 
-			var scheduledSearches []api.ScheduledSearch
-			scheduledSearches, err := client.ScheduledSearches().List(view)
-			exitOnError(cmd, err, "Error fetching scheduled searches")
-
-			for i := range scheduledSearches {
-				yamlData, err := yaml.Marshal(&scheduledSearches[i])
-				exitOnError(cmd, err, "Failed to serialize the scheduled search")
-				scheduledSearchFilename := sanitizeTriggerName(scheduledSearches[i].Name) + ".yaml"
-
-				var outFilePath string
-				if outputDirectory != "" {
-					outFilePath = outputDirectory + "/" + scheduledSearchFilename
-				} else {
-					outFilePath = scheduledSearchFilename
-				}
+			results := client.ScheduledSearches().ListAll(context.Background(), searchDomains, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching scheduled searches")
+			}
+
+			for _, result := range results {
+				scheduledSearches := result.Value
+				for i := range scheduledSearches {
+					yamlData, err := yaml.Marshal(&scheduledSearches[i])
+					exitOnError(cmd, err, "Failed to serialize the scheduled search")
+					scheduledSearchFilename := sanitizeTriggerName(scheduledSearches[i].Name) + ".yaml"
 
-				err = os.WriteFile(outFilePath, yamlData, 0600)
-				exitOnError(cmd, err, "Error saving the scheduled search to file")
+					outFilePath := exportFilePath(cmd, outputDirectory, result.Item, allViews, scheduledSearchFilename)
+					err = os.WriteFile(outFilePath, yamlData, 0600)
+					exitOnError(cmd, err, "Error saving the scheduled search to file")
+				}
 			}
+
+			exitOnBulkErrors(cmd, results, "Error fetching scheduled searches")
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputDirectory, "outputDirectory", "d", "", "The file path where the scheduled searches should be written. Defaults to current directory.")
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "Export scheduled searches from all repositories and views.")
 
 	return &cmd
 }
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"strings"
 
 	"github.com/humio/cli/internal/format"
@@ -22,40 +23,57 @@ import (
 )
 
 func newScheduledSearchesListCmd() *cobra.Command {
+	var allViews bool
+
 	cmd := cobra.Command{
-		Use:   "list <view>",
+		Use:   "list [flags] (<view> | --all-views)",
 		Short: "List all scheduled searches in a view.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			view := args[0]
 			client := NewApiClient(cmd)
+			views := searchDomainsFromArgs(cmd, client, args, allViews)
+
+			results := client.ScheduledSearches().ListAll(context.Background(), views, bulkOptions(cmd))
+			if !allViews {
+				exitOnError(cmd, results[0].Err, "Error fetching scheduled searches")
+			}
 
-			scheduledSearches, err := client.ScheduledSearches().List(view)
-			exitOnError(cmd, err, "Error fetching scheduled searches")
-
-			var rows = make([][]format.Value, len(scheduledSearches))
-			for i := range scheduledSearches {
-				scheduledSearch := scheduledSearches[i]
-				rows[i] = []format.Value{
-					format.String(scheduledSearch.ID),
-					format.String(scheduledSearch.Name),
-					format.StringPtr(scheduledSearch.Description),
-					format.String(scheduledSearch.QueryStart),
-					format.String(scheduledSearch.QueryEnd),
-					format.String(scheduledSearch.TimeZone),
-					format.String(scheduledSearch.Schedule),
-					format.Int(scheduledSearch.BackfillLimit),
-					format.String(strings.Join(scheduledSearch.ActionNames, ", ")),
-					format.String(strings.Join(scheduledSearch.Labels, ", ")),
-					format.Bool(scheduledSearch.Enabled),
-					format.String(scheduledSearch.OwnershipRunAsID),
-					format.String(scheduledSearch.QueryOwnershipType),
+			var rows [][]format.Value
+			for _, result := range results {
+				for _, scheduledSearch := range result.Value {
+					row := []format.Value{
+						format.String(scheduledSearch.ID),
+						format.String(scheduledSearch.Name),
+						format.StringPtr(scheduledSearch.Description),
+						format.String(scheduledSearch.QueryStart),
+						format.String(scheduledSearch.QueryEnd),
+						format.String(scheduledSearch.TimeZone),
+						format.String(scheduledSearch.Schedule),
+						format.Int(scheduledSearch.BackfillLimit),
+						format.String(strings.Join(scheduledSearch.ActionNames, ", ")),
+						format.String(strings.Join(scheduledSearch.Labels, ", ")),
+						format.Bool(scheduledSearch.Enabled),
+						format.String(scheduledSearch.OwnershipRunAsID),
+						format.String(scheduledSearch.QueryOwnershipType),
+					}
+					if allViews {
+						row = append([]format.Value{format.String(result.Item)}, row...)
+					}
+					rows = append(rows, row)
 				}
 			}
 
-			printOverviewTable(cmd, []string{"ID", "Name", "Description", "Query Start", "Query End", "Time Zone", "Schedule", "Backfill Limit", "Action Names", "Labels", "Enabled", "Run As User ID", "Query Ownership Type"}, rows)
+			header := []string{"ID", "Name", "Description", "Query Start", "Query End", "Time Zone", "Schedule", "Backfill Limit", "Action Names", "Labels", "Enabled", "Run As User ID", "Query Ownership Type"}
+			if allViews {
+				header = append([]string{"View"}, header...)
+			}
+			printOverviewTable(cmd, header, rows)
+
+			exitOnBulkErrors(cmd, results, "Error fetching scheduled searches")
 		},
 	}
 
+	cmd.Flags().BoolVar(&allViews, "all-views", false, "List scheduled searches in all repositories and views.")
+
 	return &cmd
 }
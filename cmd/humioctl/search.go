@@ -29,17 +29,45 @@ func newSearchCmd() *cobra.Command {
 		noWrap       bool
 		noProgress   bool
 		jsonProgress bool
+		savedQuery   string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "search [flags] <repo> <query>",
+		Use:   "search [flags] <repo> (<query> | --saved <name>)",
 		Short: "Search",
-		Args:  cobra.ExactArgs(2),
+		Long: `Run a query in a repository or view.
+
+With --saved, the query string of a saved query in the repository or view is used instead of <query>.
+The start, end and live settings of the saved query apply unless they are given as flags.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if savedQuery != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			repository := args[0]
-			queryString := args[1]
 			client := NewApiClient(cmd)
 
+			var queryString string
+			if savedQuery != "" {
+				saved, err := client.SavedQueries().Get(repository, savedQuery)
+				exitOnError(cmd, err, "Error fetching saved query")
+
+				queryString = saved.QueryString
+				if !cmd.Flags().Changed("start") && saved.Start != "" {
+					start = saved.Start
+				}
+				if !cmd.Flags().Changed("end") && saved.End != "" {
+					end = saved.End
+				}
+				if !cmd.Flags().Changed("live") {
+					live = saved.IsLive
+				}
+			} else {
+				queryString = args[1]
+			}
+
 			ctx := contextCancelledOnInterrupt(context.Background())
 
 			// get the search start time, used for json output
@@ -101,7 +129,7 @@ func newSearchCmd() *cobra.Command {
 						progress.Update(result)
 					}
 					if jsonProgress {
-						jsonProgress, _ := printQueryResultProgressJson(result, args, startMillis)
+						jsonProgress, _ := printQueryResultProgressJson(result, repository, queryString, startMillis)
 						fmt.Printf("%s\n", jsonProgress)
 					}
 					result, err = poller.WaitAndPollContext(ctx)
@@ -116,7 +144,7 @@ func newSearchCmd() *cobra.Command {
 				}
 
 				if jsonProgress {
-					jsonProgress, _ := printQueryResultProgressJson(result, args, startMillis)
+					jsonProgress, _ := printQueryResultProgressJson(result, repository, queryString, startMillis)
 					fmt.Printf("%s\n", jsonProgress)
 				}
 
@@ -161,6 +189,7 @@ func newSearchCmd() *cobra.Command {
 		"{@timestamp:-40} left aligns and right pads to 40 characters.")
 	cmd.Flags().BoolVarP(&noWrap, "no-wrap", "n", false, "Do not autowrap long strings.")
 	cmd.Flags().BoolVar(&noProgress, "no-progress", false, "Do not should progress information.")
+	cmd.Flags().StringVar(&savedQuery, "saved", "", "Run the saved query with this name instead of a query given as argument.")
 	cmd.Flags().BoolVar(&jsonProgress, "json-progress", false, "Print progress in json format. This disables progress and output, useful for logging search metadata.")
 
 	return cmd
@@ -170,7 +199,7 @@ func contextCancelledOnInterrupt(ctx context.Context) context.Context {
 	ctx, cancel := context.WithCancel(ctx)
 
 	sigC := make(chan os.Signal, 1)
-	signal.Notify(sigC, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigC, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
 	go func() {
 		<-sigC
@@ -253,7 +282,7 @@ type queryResultProgressJson struct {
 	Done        bool    `json:"done"`
 }
 
-func printQueryResultProgressJson(result api.QueryResult, args []string, startMillis int64) (string, error) {
+func printQueryResultProgressJson(result api.QueryResult, repository, queryString string, startMillis int64) (string, error) {
 	var epsValue, bpsValue float64
 
 	if result.Metadata.TimeMillis > 0 {
@@ -266,8 +295,8 @@ func printQueryResultProgressJson(result api.QueryResult, args []string, startMi
 	jsonResult := &queryResultProgressJson{
 		Timestamp:   timestamp,
 		StartMillis: startMillis,
-		Repo:        args[0],
-		QueryString: args[1],
+		Repo:        repository,
+		QueryString: queryString,
 		Start:       result.Metadata.QueryStart,
 		End:         result.Metadata.QueryEnd,
 		TotalWork:   result.Metadata.TotalWork,
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newSearchDomainsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search-domains",
+		Short: "Manage repositories and views together, e.g. restoring deleted ones",
+	}
+
+	cmd.AddCommand(newSearchDomainsListDeletedCmd())
+	cmd.AddCommand(newSearchDomainsRestoreCmd())
+
+	return cmd
+}
+
+// renameSearchDomain renames a repository or view, and warns about triggers and actions which still mention the old name.
+func renameSearchDomain(cmd *cobra.Command, client *api.Client, kind, oldName, newName string) {
+	err := client.SearchDomains().Rename(oldName, newName)
+	exitOnError(cmd, err, fmt.Sprintf("Error renaming %s", kind))
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully renamed %s %q to %q\n", kind, oldName, newName)
+
+	searchDomains, err := client.SearchDomains().List()
+	if err != nil {
+		cmd.PrintErrf("Warning: unable to check for references to %q: %s\n", oldName, err)
+		return
+	}
+	names := make([]string, len(searchDomains))
+	for i, searchDomain := range searchDomains {
+		names[i] = searchDomain.Name
+	}
+
+	results := api.Bulk(context.Background(), names, bulkOptions(cmd), func(_ context.Context, searchDomain string) ([]api.SearchDomainReference, error) {
+		return client.SearchDomains().FindReferences(searchDomain, oldName)
+	})
+	for _, result := range results {
+		if result.Err != nil {
+			cmd.PrintErrf("Warning: unable to check %q for references to %q: %s\n", result.Item, oldName, result.Err)
+			continue
+		}
+		for _, reference := range result.Value {
+			cmd.PrintErrf("Warning: %s %q in %q references the old name %q\n", reference.Kind, reference.Name, reference.SearchDomain, oldName)
+		}
+	}
+}
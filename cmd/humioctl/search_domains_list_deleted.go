@@ -0,0 +1,41 @@
+package main
+
+import (
+	"time"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newSearchDomainsListDeletedCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "list-deleted",
+		Short: "List deleted repositories and views which can still be restored.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			searchDomains, err := client.SearchDomains().ListDeleted()
+			exitOnError(cmd, err, "Error fetching deleted repositories and views")
+
+			rows := make([][]format.Value, len(searchDomains))
+			for i, searchDomain := range searchDomains {
+				permanentlyDeletedAt := ""
+				if searchDomain.PermanentlyDeletedAt != nil {
+					permanentlyDeletedAt = searchDomain.PermanentlyDeletedAt.Format(time.RFC3339)
+				}
+				rows[i] = []format.Value{
+					format.String(searchDomain.ID),
+					format.String(searchDomain.Name),
+					format.String(searchDomain.Type),
+					format.String(searchDomain.DeletedAt.Format(time.RFC3339)),
+					format.String(permanentlyDeletedAt),
+				}
+			}
+
+			printOverviewTable(cmd, []string{"ID", "Name", "Type", "Deleted At", "Permanently Deleted At"}, rows)
+		},
+	}
+
+	return &cmd
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newSearchDomainsRestoreCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "restore <id-or-name>",
+		Short: "Restore a deleted repository or view.",
+		Long: `Restore a deleted repository or view before it is permanently removed.
+
+Use "search-domains list-deleted" to find the ID of the deleted repository or view.
+The name can be used instead, unless several deleted repositories or views share it.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			searchDomain, err := client.SearchDomains().Restore(args[0])
+			exitOnError(cmd, err, "Error restoring repository or view")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully restored %q\n", searchDomain.Name)
+		},
+	}
+
+	return &cmd
+}
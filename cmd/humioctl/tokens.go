@@ -15,6 +15,9 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +27,44 @@ func newTokensCmd() *cobra.Command {
 		Short: "Manage tokens",
 	}
 
+	cmd.AddCommand(newTokensListCmd())
+	cmd.AddCommand(newTokensShowCmd())
+	cmd.AddCommand(newTokensCreateCmd())
+	cmd.AddCommand(newTokensDeleteCmd())
+	cmd.AddCommand(newTokensRotateCmd())
 	cmd.AddCommand(newTokensRotateApiTokenCmd())
 
 	return cmd
 }
+
+// printTokenSecret writes the secret of a token to secretFile, readable only by the current user,
+// or prints it if secretFile is empty.
+func printTokenSecret(cmd *cobra.Command, secretFile, secret, message string) {
+	if secretFile == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", message, secret)
+		return
+	}
+
+	err := writeSecretFile(secretFile, secret)
+	exitOnError(cmd, err, "Error writing token to file")
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s and wrote it to %s\n", message, secretFile)
+}
+
+// writeSecretFile writes secret to path. The mode of an existing file is not changed by opening it,
+// so it is restricted to the current user before the secret is written.
+func writeSecretFile(path, secret string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = file.Chmod(0600)
+	if err == nil {
+		_, err = file.WriteString(secret + "\n")
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newTokensCreateCmd() *cobra.Command {
+	var tokenType, expiresIn, secretFile string
+	var permissions, views []string
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a view, organization or system API token.",
+		Long: `Create an API token with the given permissions.
+
+View tokens use view permissions such as ReadAccess and must be given the views they apply to with --view.
+Organization tokens use organization permissions such as ManageUsers, and system tokens
+system permissions such as ReadHealthCheck.
+
+The secret of the token is only shown once. Use --secret-file to write it to a file instead of printing it.
+
+  $ humioctl tokens create ci-reader --type view --view logs --permission ReadAccess --expires-in 90d --secret-file ci.token
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			input := api.TokenInput{
+				Name:        args[0],
+				Type:        api.TokenType(tokenType),
+				Permissions: permissions,
+				Views:       views,
+			}
+			if len(permissions) == 0 {
+				exitOnError(cmd, fmt.Errorf("at least one --permission is required"), "Error creating token")
+			}
+			if expiresIn != "" {
+				duration, err := parseTokenExpiry(expiresIn)
+				exitOnError(cmd, err, "Invalid expiry")
+				expireAt := time.Now().Add(duration)
+				input.ExpireAt = &expireAt
+			}
+
+			secret, err := client.Tokens().Create(input)
+			exitOnError(cmd, err, "Error creating token")
+
+			printTokenSecret(cmd, secretFile, secret, fmt.Sprintf("Successfully created token %q", input.Name))
+		},
+	}
+
+	cmd.Flags().StringVar(&tokenType, "type", "", "The type of token, one of view, organization or system.")
+	cmd.Flags().StringSliceVar(&permissions, "permission", nil, "A permission to grant the token. Can be repeated or comma separated.")
+	cmd.Flags().StringSliceVar(&views, "view", nil, "A repository or view the token applies to, for view tokens. Can be repeated or comma separated.")
+	cmd.Flags().StringVar(&expiresIn, "expires-in", "", "How long the token is valid, e.g. 90d or 12h. By default the token does not expire.")
+	cmd.Flags().StringVar(&secretFile, "secret-file", "", "Write the token secret to this file instead of printing it.")
+	_ = cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
+// parseTokenExpiry parses a duration, which besides the units of time.ParseDuration may be given in days such as 90d.
+func parseTokenExpiry(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("expiry must be positive, got %q", s)
+	}
+	return duration, nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newTokensDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <token>",
+		Short: "Delete an API token, given by name or ID.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			token, err := client.Tokens().Get(args[0])
+			exitOnError(cmd, err, "Error fetching token")
+
+			err = client.Tokens().Delete(token.ID)
+			exitOnError(cmd, err, "Error deleting token")
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted token %q\n", token.Name)
+		},
+	}
+}
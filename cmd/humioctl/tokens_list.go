@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newTokensListCmd() *cobra.Command {
+	var tokenType string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List view, organization and system API tokens.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			tokens, err := client.Tokens().List(api.TokenType(tokenType))
+			exitOnError(cmd, err, "Error listing tokens")
+
+			rows := make([][]format.Value, len(tokens))
+			for i, token := range tokens {
+				rows[i] = []format.Value{
+					format.String(token.Name),
+					format.String(token.ID),
+					format.String(string(token.Type)),
+					format.String(strings.Join(token.Views, ", ")),
+					tokenExpiry(token),
+				}
+			}
+
+			printOverviewTable(cmd, []string{"Name", "ID", "Type", "Views", "Expires At"}, rows)
+		},
+	}
+
+	cmd.Flags().StringVar(&tokenType, "type", "", "Only list tokens of this type, one of view, organization or system.")
+
+	return cmd
+}
+
+func tokenExpiry(token api.Token) format.Value {
+	if token.ExpireAt == nil {
+		return format.String("never")
+	}
+	return format.String(token.ExpireAt.UTC().Format(time.RFC3339))
+}
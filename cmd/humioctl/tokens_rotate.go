@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newTokensRotateCmd() *cobra.Command {
+	var secretFile string
+
+	cmd := &cobra.Command{
+		Use:   "rotate <token>",
+		Short: "Rotate an API token, given by name or ID.",
+		Long: `Rotate an API token, which replaces its secret. The old secret stops working immediately.
+
+Use --secret-file to write the new secret to a file, readable only by the current user, instead of printing it.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			token, err := client.Tokens().Get(args[0])
+			exitOnError(cmd, err, "Error fetching token")
+
+			secret, err := client.Tokens().Rotate(token.ID)
+			exitOnError(cmd, err, "Error rotating token")
+
+			printTokenSecret(cmd, secretFile, secret, fmt.Sprintf("Successfully rotated token %q", token.Name))
+		},
+	}
+
+	cmd.Flags().StringVar(&secretFile, "secret-file", "", "Write the new token secret to this file instead of printing it.")
+
+	return cmd
+}
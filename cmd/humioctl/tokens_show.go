@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/humio/cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+func newTokensShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <token>",
+		Short: "Show details about an API token, given by name or ID.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := NewApiClient(cmd)
+
+			token, err := client.Tokens().Get(args[0])
+			exitOnError(cmd, err, "Error fetching token")
+
+			details := [][]format.Value{
+				{format.String("Name"), format.String(token.Name)},
+				{format.String("ID"), format.String(token.ID)},
+				{format.String("Type"), format.String(string(token.Type))},
+				{format.String("Permissions"), format.String(strings.Join(token.Permissions, ", "))},
+				{format.String("Views"), format.String(strings.Join(token.Views, ", "))},
+				{format.String("Created At"), format.String(token.CreatedAt.UTC().Format(time.RFC3339))},
+				{format.String("Expires At"), tokenExpiry(*token)},
+			}
+
+			printDetailsTable(cmd, details)
+		},
+	}
+}
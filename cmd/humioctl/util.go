@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 
+	"github.com/humio/cli/internal/api"
 	"github.com/humio/cli/internal/format"
 	"github.com/spf13/cobra"
 )
@@ -27,10 +29,41 @@ func SetVersion(v, c, d string) {
 	date = d
 }
 
+// Exit codes used by exitOnError for the kinds of errors returned by the API. These are documented in the help of the root command.
+const (
+	exitCodeError            = 1
+	exitCodeNotFound         = 3
+	exitCodePermissionDenied = 4
+	exitCodeConflict         = 5
+	exitCodeValidation       = 6
+	exitCodeRateLimited      = 7
+	exitCodeServerError      = 8
+)
+
 func exitOnError(cmd *cobra.Command, err error, message string) {
 	if err != nil {
 		cmd.Printf(message+": %s\n", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode returns the exit code for err, depending on the kind of API error it is.
+func exitCode(err error) int {
+	switch {
+	case errors.As(err, &api.NotFoundError{}):
+		return exitCodeNotFound
+	case errors.As(err, &api.PermissionDeniedError{}):
+		return exitCodePermissionDenied
+	case errors.As(err, &api.ConflictError{}):
+		return exitCodeConflict
+	case errors.As(err, &api.ValidationError{}):
+		return exitCodeValidation
+	case errors.As(err, &api.RateLimitedError{}):
+		return exitCodeRateLimited
+	case errors.As(err, &api.ServerError{}):
+		return exitCodeServerError
+	default:
+		return exitCodeError
 	}
 }
 
@@ -134,11 +167,25 @@ func (sf *float64PtrFlag) Type() string {
 }
 
 func printDetailsTable(cmd *cobra.Command, data [][]format.Value) {
-	format.FormatterFromCommand(cmd).Details(data)
+	formatter, err := format.FormatterFromCommand(cmd)
+	exitOnError(cmd, err, "Invalid output format")
+	opts, err := format.OptionsFromCommand(cmd)
+	exitOnError(cmd, err, "Invalid output options")
+	data, err = opts.ApplyDetails(data)
+	exitOnError(cmd, err, "Invalid output options")
+	err = formatter.Details(data)
+	exitOnError(cmd, err, "Error writing output")
 }
 
 func printOverviewTable(cmd *cobra.Command, header []string, data [][]format.Value) {
-	format.FormatterFromCommand(cmd).Table(header, data)
+	formatter, err := format.FormatterFromCommand(cmd)
+	exitOnError(cmd, err, "Invalid output format")
+	opts, err := format.OptionsFromCommand(cmd)
+	exitOnError(cmd, err, "Invalid output options")
+	header, data, err = opts.ApplyTable(header, data)
+	exitOnError(cmd, err, "Invalid output options")
+	err = formatter.Table(header, data)
+	exitOnError(cmd, err, "Error writing output")
 }
 
 func getBytesFromFile(filePath string) ([]byte, error) {
@@ -167,3 +214,64 @@ func getBytesFromURL(url string) ([]byte, error) {
 func sanitizeTriggerName(name string) string {
 	return regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString(name, "_")
 }
+
+// bulkOptions returns the options for commands fanning out over many search domains, as set by --concurrency and --rate-limit.
+func bulkOptions(cmd *cobra.Command) api.BulkOptions {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	ratePerSecond, _ := cmd.Flags().GetFloat64("rate-limit")
+
+	return api.BulkOptions{
+		Concurrency:   concurrency,
+		RatePerSecond: ratePerSecond,
+	}
+}
+
+// searchDomainsFromArgs returns the repository or view given as the only argument,
+// or the names of all repositories and views if allViews is set.
+func searchDomainsFromArgs(cmd *cobra.Command, client *api.Client, args []string, allViews bool) []string {
+	if !allViews {
+		if len(args) != 1 {
+			exitOnError(cmd, fmt.Errorf("expected a repository or view, or --all-views"), "Invalid arguments")
+		}
+		return args
+	}
+
+	if len(args) != 0 {
+		exitOnError(cmd, fmt.Errorf("a repository or view cannot be combined with --all-views"), "Invalid arguments")
+	}
+
+	searchDomains, err := client.SearchDomains().List()
+	exitOnError(cmd, err, "Error fetching repositories and views")
+
+	names := make([]string, len(searchDomains))
+	for i, searchDomain := range searchDomains {
+		names[i] = searchDomain.Name
+	}
+	return names
+}
+
+// exitOnBulkErrors exits if any of the search domains failed, reporting all of their errors.
+// The exit code is chosen by exitOnError from the joined errors.
+func exitOnBulkErrors[V any](cmd *cobra.Command, results []api.BulkResult[string, V], message string) {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%q: %w", result.Item, result.Err))
+		}
+	}
+	exitOnError(cmd, errors.Join(errs...), message)
+}
+
+// exportFilePath returns the path of an exported file, placed in a subdirectory per search domain when exporting from all of them.
+func exportFilePath(cmd *cobra.Command, outputDirectory, searchDomain string, allViews bool, fileName string) string {
+	if allViews {
+		outputDirectory = filepath.Join(outputDirectory, sanitizeTriggerName(searchDomain))
+		err := os.MkdirAll(outputDirectory, 0700)
+		exitOnError(cmd, err, "Error creating output directory")
+	}
+
+	if outputDirectory != "" {
+		return outputDirectory + "/" + fileName
+	}
+	return fileName
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newViewsRenameCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "rename <view> <new-name>",
+		Short: "Rename a view.",
+		Long: `Rename a view.
+
+Queries of alerts and scheduled searches, and templates of actions, are not updated.
+A warning is printed for each of them which mentions the old name.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			viewName := args[0]
+			newName := args[1]
+			client := NewApiClient(cmd)
+
+			_, err := client.Views().Get(viewName)
+			exitOnError(cmd, err, "Error fetching view")
+
+			renameSearchDomain(cmd, client, "view", viewName, newName)
+		},
+	}
+
+	return &cmd
+}
@@ -33,7 +33,7 @@ func newWelcomeCmd() *cobra.Command {
 			out.Description("This will guide you through setting up the Humio CLI.")
 			out.BlankLine()
 
-			profile, err := collectProfileInfo(cmd)
+			profile, err := collectProfileInfo(cmd, "")
 			exitOnError(cmd, err, "Failed to collect profile info")
 
 			viper.Set(viperkey.Address, profile.address)
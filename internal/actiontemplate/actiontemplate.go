@@ -0,0 +1,158 @@
+// Package actiontemplate expands the placeholders of action message templates, such as {alert_name} and
+// {field:status}, the way LogScale does when an alert or scheduled search triggers an action.
+package actiontemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Context holds the values placeholders are expanded from.
+type Context struct {
+	AlertName        string
+	AlertDescription string
+	AlertID          string
+	AlertType        string
+	ActionName       string
+	ActionType       string
+	QueryString      string
+	RepoName         string
+	URL              string
+	Warnings         string
+	QueryStart       time.Time
+	QueryEnd         time.Time
+	TriggeredAt      time.Time
+	Events           []map[string]interface{}
+	// EscapeJSON escapes values of {field:...} for use inside JSON strings, as done for webhook bodies.
+	EscapeJSON bool
+}
+
+var placeholderPattern = regexp.MustCompile(`\{(field|field_raw):([^{}]+)\}|\{([a-z_]+)\}`)
+
+// Variables are the names of the placeholders supported besides {field:<name>} and {field_raw:<name>}.
+func Variables() []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var variables = map[string]func(c Context) string{
+	"alert_name":                func(c Context) string { return c.AlertName },
+	"alert_description":         func(c Context) string { return c.AlertDescription },
+	"alert_id":                  func(c Context) string { return c.AlertID },
+	"alert_type":                func(c Context) string { return c.AlertType },
+	"alert_triggered_timestamp": func(c Context) string { return c.TriggeredAt.UTC().Format(time.RFC3339) },
+	"action_name":               func(c Context) string { return c.ActionName },
+	"action_type":               func(c Context) string { return c.ActionType },
+	"event_count":               func(c Context) string { return strconv.Itoa(len(c.Events)) },
+	"events":                    func(c Context) string { return eventsJSON(c.Events) },
+	"events_str":                func(c Context) string { return eventsString(c.Events) },
+	"events_html":               func(c Context) string { return eventsHTML(c.Events) },
+	"query_string":              func(c Context) string { return c.QueryString },
+	"query_time_start":          func(c Context) string { return c.QueryStart.UTC().Format(time.RFC3339) },
+	"query_time_end":            func(c Context) string { return c.QueryEnd.UTC().Format(time.RFC3339) },
+	"query_time_interval":       func(c Context) string { return c.QueryEnd.Sub(c.QueryStart).String() },
+	"repo_name":                 func(c Context) string { return c.RepoName },
+	"url":                       func(c Context) string { return c.URL },
+	"warnings":                  func(c Context) string { return c.Warnings },
+}
+
+// Render expands the placeholders of template. It returns the placeholders which are not supported,
+// which are left as is in the result.
+func Render(template string, c Context) (string, []string) {
+	var unknown []string
+	result := placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		match := placeholderPattern.FindStringSubmatch(placeholder)
+		switch {
+		case match[1] != "":
+			value := fieldValue(c.Events, match[2])
+			if match[1] == "field" && c.EscapeJSON {
+				value = escapeJSON(value)
+			}
+			return value
+		default:
+			variable, ok := variables[match[3]]
+			if !ok {
+				unknown = append(unknown, placeholder)
+				return placeholder
+			}
+			return variable(c)
+		}
+	})
+	return result, unknown
+}
+
+// fieldValue returns the value of a field in the first event, or an empty string if it is not present.
+func fieldValue(events []map[string]interface{}, name string) string {
+	if len(events) == 0 {
+		return ""
+	}
+	value, ok := events[0][name]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func escapeJSON(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data[1 : len(data)-1])
+}
+
+func eventsJSON(events []map[string]interface{}) string {
+	if events == nil {
+		events = []map[string]interface{}{}
+	}
+	data, _ := json.Marshal(events)
+	return string(data)
+}
+
+func eventsString(events []map[string]interface{}) string {
+	lines := make([]string, len(events))
+	for i, event := range events {
+		lines[i] = eventString(event)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func eventsHTML(events []map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString("<ul>")
+	for _, event := range events {
+		b.WriteString("<li>")
+		b.WriteString(html.EscapeString(eventString(event)))
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ul>")
+	return b.String()
+}
+
+func eventString(event map[string]interface{}) string {
+	keys := make([]string, 0, len(event))
+	for key := range event {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = key + "=" + fieldValue([]map[string]interface{}{event}, key)
+	}
+	return strings.Join(fields, ", ")
+}
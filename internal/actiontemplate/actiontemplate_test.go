@@ -0,0 +1,24 @@
+package actiontemplate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	c := Context{
+		AlertName:  "Errors",
+		Events:     []map[string]interface{}{{"message": `disk "full"`, "count": 3}},
+		EscapeJSON: true,
+	}
+
+	result, unknown := Render(`{"text": "{alert_name}: {event_count} events, {field:message} {field_raw:count} {missing}"}`, c)
+
+	expected := `{"text": "Errors: 1 events, disk \"full\" 3 {missing}"}`
+	if result != expected {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", result, expected)
+	}
+	if !reflect.DeepEqual(unknown, []string{"{missing}"}) {
+		t.Errorf("unexpected unknown placeholders: %v", unknown)
+	}
+}
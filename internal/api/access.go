@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AccessGrant describes the effective permissions a user has on a repository or view, and how they are granted.
+type AccessGrant struct {
+	Username    string
+	View        string
+	Permissions []string
+	GrantedBy   []string
+}
+
+const readAccessPermission = "ReadAccess"
+
+const (
+	// AccessOrganization is reported as the view of grants from organization roles.
+	AccessOrganization = "(organization)"
+	// AccessSystem is reported as the view of grants from system roles.
+	AccessSystem = "(system)"
+)
+
+// AccessReport returns the permissions of every user on every repository and view.
+//
+// Users get permissions by being root, through roles assigned directly to them for a view,
+// or through the view, organization and system roles assigned to their groups.
+// Organization and system permissions are reported on the pseudo views AccessOrganization and AccessSystem.
+// Users who can read a view can also read the data of the repositories it connects to,
+// which is reported as read access to those repositories granted via the view.
+// The details of each group and view are fetched in bulk with opts.
+func (c *Client) AccessReport(ctx context.Context, opts BulkOptions) ([]AccessGrant, error) {
+	users, err := c.Users().List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list users: %w", err)
+	}
+
+	userRoles, err := c.Users().SearchDomainRoles()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list roles assigned to users: %w", err)
+	}
+
+	groupList, err := c.Groups().List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list groups: %w", err)
+	}
+	groupResults := Bulk(ctx, groupList, opts, func(_ context.Context, group Group) (*Group, error) {
+		return c.Groups().GetByID(group.ID)
+	})
+	groups := make([]Group, len(groupResults))
+	for i, result := range groupResults {
+		if result.Err != nil {
+			return nil, fmt.Errorf("unable to fetch group %q: %w", result.Item.DisplayName, result.Err)
+		}
+		groups[i] = *result.Value
+	}
+
+	roles, err := c.Roles().List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list roles: %w", err)
+	}
+
+	repos, err := c.Repositories().List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list repositories: %w", err)
+	}
+
+	viewList, err := c.Views().List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list views: %w", err)
+	}
+	viewResults := Bulk(ctx, viewList, opts, func(_ context.Context, view ViewListItem) (*View, error) {
+		return c.Views().Get(view.Name)
+	})
+	views := make([]View, len(viewResults))
+	for i, result := range viewResults {
+		if result.Err != nil {
+			return nil, fmt.Errorf("unable to fetch view %q: %w", result.Item.Name, result.Err)
+		}
+		views[i] = *result.Value
+	}
+
+	return buildAccessReport(users, userRoles, groups, roles, repos, views), nil
+}
+
+func buildAccessReport(users []User, userRoles map[string][]GroupViewRole, groups []Group, roles []Role, repos []RepoListItem, views []View) []AccessGrant {
+	rolesByName := make(map[string]Role, len(roles))
+	for _, role := range roles {
+		rolesByName[role.DisplayName] = role
+	}
+
+	connections := make(map[string][]string, len(views))
+	for _, view := range views {
+		for _, connection := range view.Connections {
+			connections[view.Name] = append(connections[view.Name], connection.RepoName)
+		}
+	}
+
+	groupsByUser := map[string][]Group{}
+	for _, group := range groups {
+		for _, member := range group.Members {
+			groupsByUser[member.Username] = append(groupsByUser[member.Username], group)
+		}
+	}
+
+	type key struct{ username, view string }
+	grants := map[key]*AccessGrant{}
+	grant := func(username, view string, permissions []string, grantedBy string) {
+		k := key{username, view}
+		g, ok := grants[k]
+		if !ok {
+			g = &AccessGrant{Username: username, View: view}
+			grants[k] = g
+		}
+		g.Permissions = appendMissing(g.Permissions, permissions...)
+		g.GrantedBy = appendMissing(g.GrantedBy, grantedBy)
+	}
+
+	for _, user := range users {
+		if user.IsRoot {
+			for _, repo := range repos {
+				grant(user.Username, repo.Name, []string{"*"}, "root user")
+			}
+			for _, view := range views {
+				grant(user.Username, view.Name, []string{"*"}, "root user")
+			}
+		}
+
+		grantViewRole := func(viewRole GroupViewRole, path string) {
+			permissions := rolesByName[viewRole.Role].ViewPermissions
+			grant(user.Username, viewRole.View, permissions, path)
+
+			if containsString(permissions, readAccessPermission) {
+				for _, repoName := range connections[viewRole.View] {
+					grant(user.Username, repoName, []string{readAccessPermission}, fmt.Sprintf("via view %s, %s", viewRole.View, strings.TrimPrefix(path, "via ")))
+				}
+			}
+		}
+
+		for _, viewRole := range userRoles[user.Username] {
+			grantViewRole(viewRole, fmt.Sprintf("direct, role %s", viewRole.Role))
+		}
+
+		for _, group := range groupsByUser[user.Username] {
+			for _, viewRole := range group.ViewRoles {
+				grantViewRole(viewRole, fmt.Sprintf("via group %s, role %s", group.DisplayName, viewRole.Role))
+			}
+			for _, role := range group.OrganizationRoles {
+				grant(user.Username, AccessOrganization, rolesByName[role].OrgPermissions, fmt.Sprintf("via group %s, role %s", group.DisplayName, role))
+			}
+			for _, role := range group.SystemRoles {
+				grant(user.Username, AccessSystem, rolesByName[role].SystemPermissions, fmt.Sprintf("via group %s, role %s", group.DisplayName, role))
+			}
+		}
+	}
+
+	report := make([]AccessGrant, 0, len(grants))
+	for _, g := range grants {
+		sort.Strings(g.Permissions)
+		report = append(report, *g)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Username != report[j].Username {
+			return strings.ToLower(report[i].Username) < strings.ToLower(report[j].Username)
+		}
+		return strings.ToLower(report[i].View) < strings.ToLower(report[j].View)
+	})
+	return report
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		if !containsString(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func containsString(list []string, value string) bool {
+	for _, s := range list {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildAccessReport(t *testing.T) {
+	users := []User{{Username: "admin", IsRoot: true}, {Username: "alice"}, {Username: "bob"}}
+	userRoles := map[string][]GroupViewRole{"bob": {{View: "logs", Role: "Reader"}}}
+	groups := []Group{{
+		DisplayName:       "developers",
+		Members:           []GroupMember{{Username: "alice"}},
+		ViewRoles:         []GroupViewRole{{View: "all-logs", Role: "Reader"}},
+		OrganizationRoles: []string{"Auditor"},
+	}}
+	roles := []Role{
+		{DisplayName: "Reader", ViewPermissions: []string{"ReadAccess"}},
+		{DisplayName: "Auditor", OrgPermissions: []string{"ViewUsage"}},
+	}
+	repos := []RepoListItem{{Name: "logs"}}
+	views := []View{{Name: "all-logs", Connections: []ViewConnection{{RepoName: "logs"}}}}
+
+	expected := []AccessGrant{
+		{Username: "admin", View: "all-logs", Permissions: []string{"*"}, GrantedBy: []string{"root user"}},
+		{Username: "admin", View: "logs", Permissions: []string{"*"}, GrantedBy: []string{"root user"}},
+		{Username: "alice", View: "(organization)", Permissions: []string{"ViewUsage"}, GrantedBy: []string{"via group developers, role Auditor"}},
+		{Username: "alice", View: "all-logs", Permissions: []string{"ReadAccess"}, GrantedBy: []string{"via group developers, role Reader"}},
+		{Username: "alice", View: "logs", Permissions: []string{"ReadAccess"}, GrantedBy: []string{"via view all-logs, group developers, role Reader"}},
+		{Username: "bob", View: "logs", Permissions: []string{"ReadAccess"}, GrantedBy: []string{"direct, role Reader"}},
+	}
+
+	report := buildAccessReport(users, userRoles, groups, roles, repos, views)
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("unexpected report:\n%#v", report)
+	}
+}
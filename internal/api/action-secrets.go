@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ActionSecret is a field of an action holding a credential, such as an API key or an Authorization header.
+type ActionSecret struct {
+	// Field names the secret, e.g. "GenieKey" or "Header Authorization".
+	Field string
+	Value *string
+}
+
+var secretReferencePattern = regexp.MustCompile(`^\$\{(file:(.+)|([A-Za-z_][A-Za-z0-9_]*))\}$`)
+
+var credentialHeaderPattern = regexp.MustCompile(`(?i)auth|cookie|token|key|secret|password`)
+
+// Secrets returns the fields of the action holding credentials. Empty fields are skipped.
+//
+// The URLs of Slack and webhook actions are secrets, as webhook URLs commonly embed tokens.
+// Webhook headers are only secrets if their names suggest they carry credentials, such as
+// Authorization or X-Api-Key, so headers like Content-Type are exported as is.
+func (a *Action) Secrets() []ActionSecret {
+	var secrets []ActionSecret
+	add := func(field string, value *string) {
+		if *value != "" {
+			secrets = append(secrets, ActionSecret{Field: field, Value: value})
+		}
+	}
+
+	add("GenieKey", &a.OpsGenieAction.GenieKey)
+	add("RoutingKey", &a.PagerDutyAction.RoutingKey)
+	add("ApiToken", &a.SlackPostMessageAction.ApiToken)
+	add("Url", &a.SlackAction.Url)
+	add("IngestToken", &a.HumioRepoAction.IngestToken)
+	add("Url", &a.WebhookAction.Url)
+	for i := range a.WebhookAction.Headers {
+		if credentialHeaderPattern.MatchString(a.WebhookAction.Headers[i].Header) {
+			add("Header "+a.WebhookAction.Headers[i].Header, &a.WebhookAction.Headers[i].Value)
+		}
+	}
+
+	return secrets
+}
+
+// LiteralSecrets returns the fields of the action holding credentials which are not secret references.
+func (a *Action) LiteralSecrets() []string {
+	var fields []string
+	for _, secret := range a.Secrets() {
+		if !IsSecretReference(*secret.Value) {
+			fields = append(fields, secret.Field)
+		}
+	}
+	return fields
+}
+
+// ResolveSecrets replaces the secret references of the action with the values they refer to.
+// Relative paths of file references are resolved against baseDir, which is the directory of the action file.
+func (a *Action) ResolveSecrets(baseDir string) error {
+	for _, secret := range a.Secrets() {
+		value, err := ResolveSecretReference(*secret.Value, baseDir)
+		if err != nil {
+			return fmt.Errorf("unable to resolve %s of action %q: %w", secret.Field, a.Name, err)
+		}
+		*secret.Value = value
+	}
+	return nil
+}
+
+// IsSecretReference reports whether value refers to a secret, either as ${ENV_VAR} or ${file:path}.
+func IsSecretReference(value string) bool {
+	return secretReferencePattern.MatchString(value)
+}
+
+// ResolveSecretReference returns the value of the environment variable or the contents of the file referred to,
+// without trailing newlines. Relative file paths are resolved against baseDir.
+// Values which are not secret references are returned as is.
+func ResolveSecretReference(value, baseDir string) (string, error) {
+	match := secretReferencePattern.FindStringSubmatch(value)
+	if match == nil {
+		return value, nil
+	}
+
+	if path := match[2]; path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		// #nosec G304
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	resolved, ok := os.LookupEnv(match[3])
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", match[3])
+	}
+	return resolved, nil
+}
+
+// SecretEnvVarName returns the environment variable name used to refer to a secret field of an action when exporting it.
+// The name includes the repository or view, as actions in different views may have the same name.
+func SecretEnvVarName(searchDomainName, actionName, field string) string {
+	name := regexp.MustCompile(`([a-z0-9])([A-Z])`).ReplaceAllString(searchDomainName+"_"+actionName+"_"+field, "${1}_${2}")
+	name = regexp.MustCompile(`[^A-Z0-9]+`).ReplaceAllString(strings.ToUpper(name), "_")
+	name = strings.Trim(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestActionResolveSecrets(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("OPS_GENIE_KEY", "from-env")
+
+	action := Action{
+		Name:           "ops",
+		OpsGenieAction: OpsGenieAction{ApiUrl: "https://api.opsgenie.com", GenieKey: "${OPS_GENIE_KEY}"},
+		WebhookAction: WebhookAction{
+			Url: "https://example.com/hook",
+			Headers: []HttpHeader{
+				{Header: "Authorization", Value: "${file:" + secretFile + "}"},
+				{Header: "X-Api-Key", Value: "literal"},
+				{Header: "Content-Type", Value: "application/json"},
+			},
+		},
+	}
+
+	if literal := action.LiteralSecrets(); len(literal) != 2 || literal[0] != "Url" || literal[1] != "Header X-Api-Key" {
+		t.Errorf("unexpected literal secrets: %v", literal)
+	}
+
+	if err := action.ResolveSecrets(""); err != nil {
+		t.Fatal(err)
+	}
+	if action.OpsGenieAction.GenieKey != "from-env" || action.WebhookAction.Headers[0].Value != "from-file" {
+		t.Errorf("unexpected resolved secrets: %+v", action)
+	}
+
+	if name := SecretEnvVarName("all-logs", "my-action", "Header Authorization"); name != "ALL_LOGS_MY_ACTION_HEADER_AUTHORIZATION" {
+		t.Errorf("unexpected env var name: %q", name)
+	}
+}
+
+func TestResolveSecretReferenceRelativeToBaseDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "secrets"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secrets", "token"), []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := ResolveSecretReference("${file:secrets/token}", dir)
+	if err != nil || value != "from-file" {
+		t.Errorf("expected the file to be read relative to the base directory, got %q, %v", value, err)
+	}
+}
@@ -238,6 +238,13 @@ func (n *Actions) List(searchDomainName string) ([]Action, error) {
 	return actions, nil
 }
 
+// ListAll lists the actions of several search domains concurrently.
+func (n *Actions) ListAll(ctx context.Context, searchDomainNames []string, opts BulkOptions) []BulkResult[string, []Action] {
+	return Bulk(ctx, searchDomainNames, opts, func(_ context.Context, name string) ([]Action, error) {
+		return n.List(name)
+	})
+}
+
 func (n *Actions) Add(searchDomainName string, newAction *Action) (*Action, error) {
 	if newAction == nil {
 		return nil, fmt.Errorf("action must not be nil")
@@ -564,3 +571,133 @@ func (n *Actions) Delete(searchDomainName, actionName string) error {
 
 	return nil
 }
+
+// ActionTestResult is the outcome of firing an action with test data.
+type ActionTestResult struct {
+	Success bool
+	Message string
+}
+
+// Test fires the given action in the search domain with eventData as the triggering events, without storing it.
+// The action does not need to exist, so actions can be tested before they are added.
+func (n *Actions) Test(searchDomainName string, action *Action, triggerName, eventData string) (*ActionTestResult, error) {
+	if action == nil {
+		return nil, fmt.Errorf("action must not be nil")
+	}
+
+	var result interface {
+		GetSuccess() bool
+		GetMessage() string
+	}
+
+	switch {
+	case !reflect.ValueOf(action.EmailAction).IsZero():
+		resp, err := humiographql.TestEmailAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.EmailAction.Recipients, action.EmailAction.SubjectTemplate, action.EmailAction.BodyTemplate, action.EmailAction.UseProxy,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestEmailAction()
+		result = &respResult
+	case !reflect.ValueOf(action.HumioRepoAction).IsZero():
+		resp, err := humiographql.TestHumioRepoAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.HumioRepoAction.IngestToken,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestHumioRepoAction()
+		result = &respResult
+	case !reflect.ValueOf(action.OpsGenieAction).IsZero():
+		resp, err := humiographql.TestOpsGenieAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.OpsGenieAction.ApiUrl, action.OpsGenieAction.GenieKey, action.OpsGenieAction.UseProxy,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestOpsGenieAction()
+		result = &respResult
+	case !reflect.ValueOf(action.PagerDutyAction).IsZero():
+		resp, err := humiographql.TestPagerDutyAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.PagerDutyAction.Severity, action.PagerDutyAction.RoutingKey, action.PagerDutyAction.UseProxy,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestPagerDutyAction()
+		result = &respResult
+	case !reflect.ValueOf(action.SlackAction).IsZero():
+		resp, err := humiographql.TestSlackAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.SlackAction.Url, slackFieldInputs(action.SlackAction.Fields), action.SlackAction.UseProxy,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestSlackAction()
+		result = &respResult
+	case !reflect.ValueOf(action.SlackPostMessageAction).IsZero():
+		resp, err := humiographql.TestSlackPostMessageAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.SlackPostMessageAction.ApiToken, action.SlackPostMessageAction.Channels,
+			slackFieldInputs(action.SlackPostMessageAction.Fields), action.SlackPostMessageAction.UseProxy,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestSlackPostMessageAction()
+		result = &respResult
+	case !reflect.ValueOf(action.VictorOpsAction).IsZero():
+		resp, err := humiographql.TestVictorOpsAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.VictorOpsAction.MessageType, action.VictorOpsAction.NotifyUrl, action.VictorOpsAction.UseProxy,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestVictorOpsAction()
+		result = &respResult
+	case !reflect.ValueOf(action.UploadFileAction).IsZero():
+		resp, err := humiographql.TestUploadFileAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.UploadFileAction.FileName,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestUploadFileAction()
+		result = &respResult
+	case !reflect.ValueOf(action.WebhookAction).IsZero():
+		headers := make([]humiographql.HttpHeaderEntryInput, len(action.WebhookAction.Headers))
+		for idx, header := range action.WebhookAction.Headers {
+			headers[idx] = humiographql.HttpHeaderEntryInput{
+				Header: header.Header,
+				Value:  header.Value,
+			}
+		}
+		resp, err := humiographql.TestWebhookAction(context.Background(), n.client, searchDomainName, action.Name,
+			action.WebhookAction.Url, action.WebhookAction.Method, headers, action.WebhookAction.BodyTemplate,
+			action.WebhookAction.IgnoreSSL, action.WebhookAction.UseProxy,
+			triggerName, eventData)
+		if err != nil {
+			return nil, err
+		}
+		respResult := resp.GetTestWebhookAction()
+		result = &respResult
+	default:
+		return nil, fmt.Errorf("no action details specified or unsupported action type used")
+	}
+
+	return &ActionTestResult{
+		Success: result.GetSuccess(),
+		Message: result.GetMessage(),
+	}, nil
+}
+
+func slackFieldInputs(fields []SlackField) []humiographql.SlackFieldEntryInput {
+	inputs := make([]humiographql.SlackFieldEntryInput, len(fields))
+	for idx, field := range fields {
+		inputs[idx] = humiographql.SlackFieldEntryInput{
+			FieldName: field.FieldName,
+			Value:     field.Value,
+		}
+	}
+	return inputs
+}
@@ -22,6 +22,8 @@ type AggregateAlert struct {
 	TriggerMode           string  `yaml:"triggerMode"`
 	QueryTimestampType    string  `yaml:"queryTimestampType"`
 	OwnershipRunAsID      string  `yaml:"ownershipRunAsID"`
+	LastError             *string `yaml:"-"`
+	LastTriggered         *int64  `yaml:"-"`
 }
 
 type AggregateAlerts struct {
@@ -56,6 +58,8 @@ func (a *AggregateAlerts) List(searchDomainName string) ([]AggregateAlert, error
 			ActionNames:           actionNames,
 			Labels:                aggregateAlert.GetLabels(),
 			Enabled:               aggregateAlert.GetEnabled(),
+			LastError:             aggregateAlert.GetLastError(),
+			LastTriggered:         aggregateAlert.GetLastTriggered(),
 			ThrottleField:         aggregateAlert.ThrottleField,
 			ThrottleTimeSeconds:   aggregateAlert.GetThrottleTimeSeconds(),
 			QueryOwnershipType:    string(queryOwnershipToQueryOwnershipType(aggregateAlert.GetQueryOwnership())),
@@ -67,6 +71,13 @@ func (a *AggregateAlerts) List(searchDomainName string) ([]AggregateAlert, error
 	return aggregateAlerts, nil
 }
 
+// ListAll lists the aggregate alerts of several search domains concurrently.
+func (a *AggregateAlerts) ListAll(ctx context.Context, searchDomainNames []string, opts BulkOptions) []BulkResult[string, []AggregateAlert] {
+	return Bulk(ctx, searchDomainNames, opts, func(_ context.Context, name string) ([]AggregateAlert, error) {
+		return a.List(name)
+	})
+}
+
 func (a *AggregateAlerts) Create(searchDomainName string, newAggregateAlert *AggregateAlert) (*AggregateAlert, error) {
 	if searchDomainName == "" {
 		return nil, fmt.Errorf("viewName must not be empty")
@@ -117,6 +128,8 @@ func (a *AggregateAlerts) Create(searchDomainName string, newAggregateAlert *Agg
 		ActionNames:           actionNames,
 		Labels:                respAggregateAlert.GetLabels(),
 		Enabled:               respAggregateAlert.GetEnabled(),
+		LastError:             respAggregateAlert.GetLastError(),
+		LastTriggered:         respAggregateAlert.GetLastTriggered(),
 		ThrottleField:         respAggregateAlert.ThrottleField,
 		ThrottleTimeSeconds:   respAggregateAlert.GetThrottleTimeSeconds(),
 		QueryOwnershipType:    string(queryOwnershipToQueryOwnershipType(respAggregateAlert.GetQueryOwnership())),
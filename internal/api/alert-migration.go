@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/humio/cli/internal/api/humiographql"
+)
+
+// AlertMigration describes how a legacy alert translates into a filter alert or an aggregate alert.
+type AlertMigration struct {
+	Kind           TriggerKind
+	FilterAlert    *FilterAlert
+	AggregateAlert *AggregateAlert
+	// Notes describe settings of the legacy alert which could not be translated exactly.
+	Notes []string
+}
+
+// SuggestedKind asks LogScale whether the query of a legacy alert is suited for a filter alert or an aggregate alert.
+// It returns an empty kind if the query can only be used by a legacy alert, and a ValidationError if the query is invalid.
+func (a *Alerts) SuggestedKind(searchDomainName string, queryString string) (TriggerKind, error) {
+	resp, err := humiographql.AnalyzeAlertQuery(context.Background(), a.client, searchDomainName, queryString)
+	if err != nil {
+		return "", err
+	}
+
+	analysis := resp.GetAnalyzeQuery()
+	validation := analysis.GetValidateQuery()
+	if !validation.GetIsValid() {
+		var messages []string
+		for _, diagnostic := range validation.GetDiagnostics() {
+			messages = append(messages, diagnostic.GetMessage())
+		}
+		return "", ValidationError{Message: fmt.Sprintf("invalid query: %s", strings.Join(messages, "; "))}
+	}
+
+	suggested := analysis.GetSuggestedAlertType()
+	if suggested == nil {
+		return "", nil
+	}
+	switch suggested.GetAlertType() {
+	case humiographql.AlertTypeFilteralert:
+		return TriggerKindFilterAlert, nil
+	case humiographql.AlertTypeAggregatealert:
+		return TriggerKindAggregateAlert, nil
+	default:
+		return "", nil
+	}
+}
+
+// PlanAlertMigration translates a legacy alert into an alert of the given kind, which is created disabled.
+// Filter alerts run on each event and have no search interval, while aggregate alerts search an interval
+// taken from the query start of the legacy alert. Throttle times are rounded up to whole seconds.
+func PlanAlertMigration(alert Alert, kind TriggerKind) (AlertMigration, error) {
+	migration := AlertMigration{Kind: kind}
+
+	throttleTimeSeconds := (alert.ThrottleTimeMillis + 999) / 1000
+	if alert.ThrottleTimeMillis%1000 != 0 {
+		migration.Notes = append(migration.Notes, fmt.Sprintf("throttle time of %dms rounded up to %ds", alert.ThrottleTimeMillis, throttleTimeSeconds))
+	}
+
+	switch kind {
+	case TriggerKindFilterAlert:
+		migration.FilterAlert = &FilterAlert{
+			Name:                alert.Name,
+			Description:         alert.Description,
+			QueryString:         alert.QueryString,
+			ActionNames:         alert.Actions,
+			Labels:              alert.Labels,
+			Enabled:             false,
+			QueryOwnershipType:  alert.QueryOwnershipType,
+			ThrottleTimeSeconds: &throttleTimeSeconds,
+			ThrottleField:       alert.ThrottleField,
+			OwnershipRunAsID:    alert.RunAsUserID,
+		}
+		migration.Notes = append(migration.Notes, fmt.Sprintf("query start %s is not used, filter alerts run on each event", alert.QueryStart))
+	case TriggerKindAggregateAlert:
+		searchInterval, err := ParseRelativeTime(alert.QueryStart)
+		if err != nil {
+			return migration, ValidationError{Message: fmt.Sprintf("unable to use query start %q as search interval: %s", alert.QueryStart, err)}
+		}
+		searchIntervalSeconds := int64(searchInterval / time.Second)
+		if throttleTimeSeconds == 0 {
+			throttleTimeSeconds = searchIntervalSeconds
+			migration.Notes = append(migration.Notes, fmt.Sprintf("no throttle time, using the search interval of %ds", searchIntervalSeconds))
+		}
+		migration.AggregateAlert = &AggregateAlert{
+			Name:                  alert.Name,
+			Description:           alert.Description,
+			QueryString:           alert.QueryString,
+			SearchIntervalSeconds: searchIntervalSeconds,
+			ActionNames:           alert.Actions,
+			Labels:                alert.Labels,
+			Enabled:               false,
+			ThrottleField:         alert.ThrottleField,
+			ThrottleTimeSeconds:   throttleTimeSeconds,
+			QueryOwnershipType:    alert.QueryOwnershipType,
+			TriggerMode:           string(humiographql.TriggerModeCompletemode),
+			QueryTimestampType:    string(humiographql.QueryTimestampTypeEventtimestamp),
+			OwnershipRunAsID:      alert.RunAsUserID,
+		}
+	default:
+		return migration, ValidationError{Message: fmt.Sprintf("cannot migrate a legacy alert to a %s", kind)}
+	}
+
+	return migration, nil
+}
+
+var relativeTimePattern = regexp.MustCompile(`^\s*(\d+)\s*([a-zA-Z]+)\s*$`)
+
+// ParseRelativeTime parses a relative time as used for the query start of alerts, such as 24h or 30 minutes.
+func ParseRelativeTime(s string) (time.Duration, error) {
+	match := relativeTimePattern.FindStringSubmatch(s)
+	if match == nil {
+		return 0, fmt.Errorf("expected a number followed by a unit, such as 1h")
+	}
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	var unit time.Duration
+	switch strings.ToLower(match[2]) {
+	case "s", "sec", "secs", "second", "seconds":
+		unit = time.Second
+	case "m", "min", "mins", "minute", "minutes":
+		unit = time.Minute
+	case "h", "hr", "hrs", "hour", "hours":
+		unit = time.Hour
+	case "d", "day", "days":
+		unit = 24 * time.Hour
+	case "w", "week", "weeks":
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("unknown unit %q", match[2])
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestPlanAlertMigration(t *testing.T) {
+	alert := Alert{
+		Name:               "errors",
+		QueryString:        "error | count()",
+		QueryStart:         "15 minutes",
+		ThrottleTimeMillis: 1500,
+		Actions:            []string{"action-id"},
+		QueryOwnershipType: "Organization",
+	}
+
+	migration, err := PlanAlertMigration(alert, TriggerKindAggregateAlert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggregateAlert := migration.AggregateAlert
+	if aggregateAlert == nil || aggregateAlert.SearchIntervalSeconds != 900 || aggregateAlert.ThrottleTimeSeconds != 2 || aggregateAlert.Enabled {
+		t.Errorf("unexpected aggregate alert: %+v", aggregateAlert)
+	}
+	if len(migration.Notes) != 1 {
+		t.Errorf("expected a note about rounding the throttle time, got %q", migration.Notes)
+	}
+
+	alert.ThrottleTimeMillis = 0
+	migration, err = PlanAlertMigration(alert, TriggerKindAggregateAlert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migration.AggregateAlert.ThrottleTimeSeconds != 900 {
+		t.Errorf("expected the search interval as throttle time, got %d", migration.AggregateAlert.ThrottleTimeSeconds)
+	}
+
+	migration, err = PlanAlertMigration(alert, TriggerKindFilterAlert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migration.FilterAlert == nil || *migration.FilterAlert.ThrottleTimeSeconds != 0 || migration.FilterAlert.ActionNames[0] != "action-id" {
+		t.Errorf("unexpected filter alert: %+v", migration.FilterAlert)
+	}
+
+	alert.QueryStart = "soon"
+	if _, err := PlanAlertMigration(alert, TriggerKindAggregateAlert); err == nil {
+		t.Error("expected an error for a query start which is not a relative time")
+	}
+}
@@ -66,6 +66,13 @@ func (a *Alerts) List(searchDomainName string) ([]Alert, error) {
 	return alerts, nil
 }
 
+// ListAll lists the alerts of several search domains concurrently.
+func (a *Alerts) ListAll(ctx context.Context, searchDomainNames []string, opts BulkOptions) []BulkResult[string, []Alert] {
+	return Bulk(ctx, searchDomainNames, opts, func(_ context.Context, name string) ([]Alert, error) {
+		return a.List(name)
+	})
+}
+
 func (a *Alerts) Add(searchDomainName string, newAlert *Alert) (*Alert, error) {
 	if newAlert == nil {
 		return nil, fmt.Errorf("newAlert must not be nil")
@@ -158,6 +165,6 @@ func (a *Alerts) Delete(searchDomainName, alertName string) error {
 		return AlertNotFound(alertName)
 	}
 
-	_, err = humiographql.DeleteAlert(context.Background(), a.client, searchDomainName, alertName)
+	_, err = humiographql.DeleteAlert(context.Background(), a.client, searchDomainName, alertId)
 	return err
 }
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// BacktestWindow holds the events found by running an alert query over one search interval in the past.
+type BacktestWindow struct {
+	Start  time.Time
+	End    time.Time
+	Events []map[string]interface{}
+}
+
+// BacktestWindows splits the period ending at end into consecutive windows of the given interval, oldest first.
+// The first window is shortened if period is not a multiple of interval.
+func BacktestWindows(end time.Time, period, interval time.Duration) []BacktestWindow {
+	var windows []BacktestWindow
+	start := end.Add(-period)
+	for windowEnd := end; windowEnd.After(start); windowEnd = windowEnd.Add(-interval) {
+		windowStart := windowEnd.Add(-interval)
+		if windowStart.Before(start) {
+			windowStart = start
+		}
+		windows = append([]BacktestWindow{{Start: windowStart, End: windowEnd}}, windows...)
+	}
+	return windows
+}
+
+// CountTriggers returns how many times an alert would have fired its actions over the windows, which must be ordered
+// oldest first. An alert fires at the end of a window with results, unless it fired less than throttle earlier.
+// With a throttle field, the throttling applies separately to each value of that field.
+func CountTriggers(windows []BacktestWindow, throttle time.Duration, throttleField *string) int {
+	lastFired := map[string]time.Time{}
+	triggers := 0
+	for _, window := range windows {
+		keys := map[string]bool{}
+		for _, event := range window.Events {
+			key := ""
+			if throttleField != nil {
+				key = fmt.Sprint(event[*throttleField])
+			}
+			keys[key] = true
+		}
+
+		fired := false
+		for key := range keys {
+			if last, ok := lastFired[key]; ok && window.End.Sub(last) < throttle {
+				continue
+			}
+			lastFired[key] = window.End
+			fired = true
+		}
+		if fired {
+			triggers++
+		}
+	}
+	return triggers
+}
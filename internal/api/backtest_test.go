@@ -0,0 +1,38 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBacktestWindows(t *testing.T) {
+	end := time.Unix(10000, 0)
+	windows := BacktestWindows(end, 25*time.Minute, 10*time.Minute)
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(windows))
+	}
+	if !windows[0].Start.Equal(end.Add(-25*time.Minute)) || !windows[0].End.Equal(end.Add(-20*time.Minute)) {
+		t.Errorf("expected a shortened first window, got %v to %v", windows[0].Start, windows[0].End)
+	}
+	if !windows[2].End.Equal(end) {
+		t.Errorf("expected the last window to end at %v, got %v", end, windows[2].End)
+	}
+}
+
+func TestCountTriggers(t *testing.T) {
+	host := "host"
+	windows := BacktestWindows(time.Unix(10000, 0), 4*time.Minute, time.Minute)
+	windows[0].Events = []map[string]interface{}{{"host": "a"}}
+	windows[1].Events = []map[string]interface{}{{"host": "a"}, {"host": "b"}}
+	windows[3].Events = []map[string]interface{}{{"host": "a"}}
+
+	if triggers := CountTriggers(windows, 0, nil); triggers != 3 {
+		t.Errorf("expected 3 triggers without throttling, got %d", triggers)
+	}
+	if triggers := CountTriggers(windows, 5*time.Minute, nil); triggers != 1 {
+		t.Errorf("expected 1 trigger when throttled, got %d", triggers)
+	}
+	if triggers := CountTriggers(windows, 5*time.Minute, &host); triggers != 2 {
+		t.Errorf("expected 2 triggers when throttled by host, got %d", triggers)
+	}
+}
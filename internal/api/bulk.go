@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultBulkConcurrency is the number of concurrent requests used by bulk operations unless configured otherwise.
+const DefaultBulkConcurrency = 4
+
+// BulkOptions controls how a bulk operation spreads its requests over time.
+type BulkOptions struct {
+	// Concurrency is the number of items processed at the same time. Defaults to DefaultBulkConcurrency.
+	Concurrency int
+	// RatePerSecond caps how many items are started per second. Zero means no cap.
+	RatePerSecond float64
+}
+
+// BulkResult holds the outcome of a bulk operation for a single item.
+type BulkResult[K any, V any] struct {
+	Item  K
+	Value V
+	Err   error
+}
+
+// Bulk calls fn for each item using a pool of workers, and returns the results in the order of items.
+// A failing item does not stop the remaining items from being processed, but cancelling ctx does.
+func Bulk[K any, V any](ctx context.Context, items []K, opts BulkOptions, fn func(ctx context.Context, item K) (V, error)) []BulkResult[K, V] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	if concurrency > len(items) {
+		concurrency = len(items)
+	}
+
+	var tick <-chan time.Time
+	if opts.RatePerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RatePerSecond))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	results := make([]BulkResult[K, V], len(items))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i].Item = items[i]
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Value, results[i].Err = fn(ctx, items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		if tick != nil && i > 0 {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+			}
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// BulkErrors returns the errors of the failed items.
+func BulkErrors[K any, V any](results []BulkResult[K, V]) []error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errs
+}
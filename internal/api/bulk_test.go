@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkResultOrder(t *testing.T) {
+	items := []int{5, 4, 3, 2, 1}
+	results := Bulk(context.Background(), items, BulkOptions{Concurrency: 3}, func(_ context.Context, item int) (string, error) {
+		// Later items finish first.
+		time.Sleep(time.Duration(item) * time.Millisecond)
+		return fmt.Sprint(item * 10), nil
+	})
+
+	if len(results) != len(items) {
+		t.Fatalf("expected %d results, got %d", len(items), len(results))
+	}
+	for i, result := range results {
+		if result.Item != items[i] || result.Value != fmt.Sprint(items[i]*10) || result.Err != nil {
+			t.Errorf("result %d: unexpected %+v", i, result)
+		}
+	}
+}
+
+func TestBulkConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	Bulk(context.Background(), make([]int, 20), BulkOptions{Concurrency: 3}, func(_ context.Context, _ int) (struct{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return struct{}{}, nil
+	})
+
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 items to be processed at the same time, got %d", maxRunning)
+	}
+}
+
+func TestBulkRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var starts []time.Time
+	Bulk(context.Background(), make([]int, 4), BulkOptions{Concurrency: 4, RatePerSecond: 50}, func(_ context.Context, _ int) (struct{}, error) {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+		return struct{}{}, nil
+	})
+
+	// At 50 items per second, the fourth item starts no earlier than 60ms after the first.
+	if elapsed := starts[len(starts)-1].Sub(starts[0]); elapsed < 55*time.Millisecond {
+		t.Errorf("expected items to be started at most 50 per second, 4 items took %s", elapsed)
+	}
+}
+
+func TestBulkCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int32
+	results := Bulk(ctx, []int{1, 2, 3, 4, 5}, BulkOptions{Concurrency: 1}, func(_ context.Context, item int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if item == 2 {
+			cancel()
+		}
+		return item, nil
+	})
+
+	if calls != 2 {
+		t.Errorf("expected no items to be processed after cancelling, got %d calls", calls)
+	}
+	for i, result := range results {
+		if result.Item != i+1 {
+			t.Errorf("result %d: expected item %d, got %d", i, i+1, result.Item)
+		}
+		if i >= 2 && !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("result %d: expected context.Canceled, got %v", i, result.Err)
+		}
+	}
+}
+
+func TestBulkErrors(t *testing.T) {
+	results := Bulk(context.Background(), []int{1, 2, 3, 4}, BulkOptions{}, func(_ context.Context, item int) (int, error) {
+		if item%2 == 0 {
+			return 0, fmt.Errorf("item %d failed", item)
+		}
+		return item, nil
+	})
+
+	errs := BulkErrors(results)
+	if fmt.Sprint(errs) != "[item 2 failed item 4 failed]" {
+		t.Errorf("expected the errors of the failed items in order, got %v", errs)
+	}
+	if errs := BulkErrors([]BulkResult[int, int]{{Item: 1}}); errs != nil {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
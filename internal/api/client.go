@@ -23,6 +23,7 @@ const defaultUserAgent = "Humio-go-client/unknown"
 type Client struct {
 	config        Config
 	httpTransport *http.Transport
+	roundTripper  http.RoundTripper
 }
 
 type Response struct {
@@ -78,6 +79,11 @@ func (err *GraphqlError) pathString() string {
 	return err.Path.String()
 }
 
+// Unwrap returns the typed error derived from the extensions of the error, if any.
+func (err *GraphqlError) Unwrap() error {
+	return err.Err
+}
+
 func (errs ErrorList) Error() string {
 	var buf bytes.Buffer
 	for _, err := range errs {
@@ -87,6 +93,15 @@ func (errs ErrorList) Error() string {
 	return buf.String()
 }
 
+// Unwrap returns the individual errors, allowing errors.As to find the typed error of any of them.
+func (errs ErrorList) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, err := range errs {
+		unwrapped[i] = err
+	}
+	return unwrapped
+}
+
 func (c *Client) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
 	var httpReq *http.Request
 	var err error
@@ -132,7 +147,7 @@ func (c *Client) MakeRequest(ctx context.Context, req *graphql.Request, resp *gr
 		if err != nil {
 			respBody = []byte(fmt.Sprintf("<unreadable: %v>", err))
 		}
-		return fmt.Errorf("returned error %v: %s", httpResp.Status, respBody)
+		return errorFromStatus(httpResp, fmt.Sprintf("returned error %v: %s", httpResp.Status, respBody))
 	}
 
 	var actualResponse Response
@@ -141,6 +156,7 @@ func (c *Client) MakeRequest(ctx context.Context, req *graphql.Request, resp *gr
 	err = json.NewDecoder(httpResp.Body).Decode(&actualResponse)
 	resp.Extensions = actualResponse.Extensions
 	for _, actualError := range actualResponse.Errors {
+		actualError.Err = errorFromGraphql(actualError)
 		gqlError := gqlerror.Error{
 			Err:        actualError.Err,
 			Message:    actualError.Message,
@@ -171,10 +187,13 @@ type Config struct {
 	Address           *url.URL
 	UserAgent         string
 	Token             string
+	TokenProvider     TokenProvider
 	CACertificatePEM  string
 	Insecure          bool
 	ProxyOrganization string
 	DialContext       func(ctx context.Context, network, addr string) (net.Conn, error)
+	// WrapTransport, if set, wraps the transport used for all requests, e.g. to record or replay them.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 func DefaultConfig() Config {
@@ -217,9 +236,15 @@ func NewClientWithTransport(config Config, httpTransport *http.Transport) *Clien
 		config.UserAgent = defaultUserAgent
 	}
 
+	var roundTripper http.RoundTripper = httpTransport
+	if config.WrapTransport != nil {
+		roundTripper = config.WrapTransport(httpTransport)
+	}
+
 	return &Client{
 		config:        config,
 		httpTransport: httpTransport,
+		roundTripper:  roundTripper,
 	}
 }
 
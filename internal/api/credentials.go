@@ -0,0 +1,214 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenProvider supplies API tokens on demand instead of using the static Config.Token.
+// Invalidate is called when the server rejects a token, so the next call to Token fetches a new one.
+type TokenProvider interface {
+	Token() (string, error)
+	Invalidate()
+}
+
+// ExecCredential is the JSON document a credential command must print on stdout.
+// Expiry is optional, tokens without an expiry are reused until the server rejects them.
+type ExecCredential struct {
+	Token  string     `json:"token"`
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// tokenExpiryMargin is subtracted from the expiry reported by the credential command,
+// so that a token is not used right before it expires.
+const tokenExpiryMargin = 30 * time.Second
+
+// ExecTokenProvider obtains tokens by running an external command, similar to kubectl exec credentials.
+type ExecTokenProvider struct {
+	command   []string
+	cacheFile string
+
+	mu         sync.Mutex
+	credential *ExecCredential
+}
+
+// NewExecTokenProvider returns a TokenProvider which runs command to obtain tokens.
+// If cacheFile is not empty, tokens with an expiry are cached in that file until they expire.
+func NewExecTokenProvider(command []string, cacheFile string) (*ExecTokenProvider, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("credential command must not be empty")
+	}
+
+	return &ExecTokenProvider{
+		command:   command,
+		cacheFile: cacheFile,
+	}, nil
+}
+
+// SplitCommandLine splits a command line into the program and its arguments the way a POSIX shell does,
+// without expanding variables or globs. Single quotes keep their contents as is, double quotes allow
+// backslash escapes of ", \, $ and `, and a backslash outside quotes escapes the next character.
+func SplitCommandLine(commandLine string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range commandLine {
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("\"\\$`", r) {
+				arg.WriteRune('\\')
+			}
+			arg.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped || quote != 0 {
+		return nil, fmt.Errorf("unterminated quote or escape in command %q", commandLine)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+func (p *ExecTokenProvider) Token() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.credential.valid() {
+		return p.credential.Token, nil
+	}
+
+	if cached := p.readCache(); cached.valid() {
+		p.credential = cached
+		return cached.Token, nil
+	}
+
+	credential, err := p.run()
+	if err != nil {
+		return "", err
+	}
+	p.credential = credential
+	p.writeCache(credential)
+
+	return credential.Token, nil
+}
+
+func (p *ExecTokenProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.credential = nil
+	if p.cacheFile != "" {
+		_ = os.Remove(p.cacheFile)
+	}
+}
+
+func (p *ExecTokenProvider) run() (*ExecCredential, error) {
+	var stdout, stderr bytes.Buffer
+
+	// #nosec G204
+	cmd := exec.Command(p.command[0], p.command[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running credential command %q: %w: %s", p.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var credential ExecCredential
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return nil, fmt.Errorf("invalid output from credential command %q: %w", p.command[0], err)
+	}
+	if credential.Token == "" {
+		return nil, fmt.Errorf("credential command %q did not return a token", p.command[0])
+	}
+
+	return &credential, nil
+}
+
+func (p *ExecTokenProvider) readCache() *ExecCredential {
+	if p.cacheFile == "" {
+		return nil
+	}
+
+	// #nosec G304
+	data, err := os.ReadFile(p.cacheFile)
+	if err != nil {
+		return nil
+	}
+
+	var credential ExecCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return nil
+	}
+
+	return &credential
+}
+
+func (p *ExecTokenProvider) writeCache(credential *ExecCredential) {
+	// Tokens without an expiry are never written to disk as we cannot know when to discard them.
+	if p.cacheFile == "" || credential.Expiry == nil {
+		return
+	}
+
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.cacheFile), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(p.cacheFile, data, 0600)
+}
+
+func (c *ExecCredential) valid() bool {
+	if c == nil || c.Token == "" {
+		return false
+	}
+	if c.Expiry == nil {
+		return true
+	}
+
+	return time.Now().Add(tokenExpiryMargin).Before(*c.Expiry)
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// credentialScript writes a credential command which returns token-1, token-2, ... on each run,
+// expiring after the given duration, and returns the command and the file counting the runs.
+func credentialScript(t *testing.T, expiresIn time.Duration) ([]string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credential script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "credential.sh")
+	counter := filepath.Join(dir, "runs")
+	content := `#!/bin/sh
+n=$(( $(cat "$1" 2>/dev/null || echo 0) + 1 ))
+echo $n > "$1"
+printf '{"token": "token-%d", "expiry": "%s"}' $n "$2"
+`
+	if err := os.WriteFile(script, []byte(content), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return []string{script, counter, time.Now().Add(expiresIn).Format(time.RFC3339)}, counter
+}
+
+func credentialRuns(t *testing.T, counter string) string {
+	t.Helper()
+	data, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func TestExecTokenProviderCache(t *testing.T) {
+	command, counter := credentialScript(t, time.Hour)
+	cacheFile := filepath.Join(t.TempDir(), "cache", "token.json")
+
+	for i := 0; i < 2; i++ {
+		// A new provider, as in a new humioctl process, reuses the token from the cache file.
+		provider, err := NewExecTokenProvider(command, cacheFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := provider.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "token-1" {
+			t.Errorf("expected the cached token-1, got %q", token)
+		}
+	}
+	if runs := credentialRuns(t, counter); runs != "1" {
+		t.Errorf("expected the credential command to run once, got %s runs", runs)
+	}
+
+	provider, err := NewExecTokenProvider(command, cacheFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.Invalidate()
+	if _, err := os.Stat(cacheFile); !os.IsNotExist(err) {
+		t.Errorf("expected Invalidate to remove the cache file, got %v", err)
+	}
+	if token, err := provider.Token(); err != nil || token != "token-2" {
+		t.Errorf("expected a new token-2 after Invalidate, got %q, %v", token, err)
+	}
+}
+
+func TestExecTokenProviderRefreshesExpiredTokens(t *testing.T) {
+	// Tokens expiring within tokenExpiryMargin are treated as expired.
+	command, counter := credentialScript(t, tokenExpiryMargin/2)
+	provider, err := NewExecTokenProvider(command, filepath.Join(t.TempDir(), "token.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"token-1", "token-2"} {
+		token, err := provider.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != expected {
+			t.Errorf("expected %q, got %q", expected, token)
+		}
+	}
+	if runs := credentialRuns(t, counter); runs != "2" {
+		t.Errorf("expected the credential command to run for each expired token, got %s runs", runs)
+	}
+}
+
+func TestHeaderTransportRetriesOnceOnUnauthorized(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Header.Get("Authorization")+" "+string(body))
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	command, _ := credentialScript(t, time.Hour)
+	provider, err := NewExecTokenProvider(command, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &headerTransport{base: http.DefaultTransport, tokenProvider: provider}}
+
+	post := func() int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte("query")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post(); status != http.StatusOK {
+		t.Errorf("expected the retry with a new token to succeed, got status %d", status)
+	}
+	expected := []string{"Bearer token-1 query", "Bearer token-2 query"}
+	if strings.Join(requests, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected requests %q, got %q", expected, requests)
+	}
+
+	// token-3 is rejected as well, and the request is not retried a second time.
+	requests = nil
+	provider.Invalidate()
+	if status := post(); status != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, status)
+	}
+	expected = []string{"Bearer token-3 query", "Bearer token-4 query"}
+	if strings.Join(requests, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected requests %q, got %q", expected, requests)
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := map[string][]string{
+		"vault-humio-token --cluster prod":           {"vault-humio-token", "--cluster", "prod"},
+		`vault read -field="token" "secret/my path"`: {"vault", "read", "-field=token", "secret/my path"},
+		`sh -c 'echo "$TOKEN"'`:                      {"sh", "-c", `echo "$TOKEN"`},
+		`get-token my\ path "a \"quoted\" \w" ''`:    {"get-token", "my path", `a "quoted" \w`, ""},
+		"  get-token\t--verbose  ":                   {"get-token", "--verbose"},
+	}
+	for commandLine, expected := range tests {
+		args, err := SplitCommandLine(commandLine)
+		if err != nil || strings.Join(args, "|") != strings.Join(expected, "|") || len(args) != len(expected) {
+			t.Errorf("%s: expected %q, got %q, %v", commandLine, expected, args, err)
+		}
+	}
+
+	for _, commandLine := range []string{`get-token "unterminated`, `get-token 'unterminated`, `get-token \`} {
+		if _, err := SplitCommandLine(commandLine); err == nil {
+			t.Errorf("%s: expected an error", commandLine)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/humio/cli/internal/api/humiographql"
+)
+
+type Dashboard struct {
+	ID             string
+	Name           string
+	Description    *string
+	Labels         []string
+	WidgetCount    int
+	ParameterCount int
+}
+
+type Dashboards struct {
+	client *Client
+}
+
+func (c *Client) Dashboards() *Dashboards { return &Dashboards{client: c} }
+
+func (d *Dashboards) List(searchDomainName string) ([]Dashboard, error) {
+	if searchDomainName == "" {
+		return nil, fmt.Errorf("searchDomainName must not be empty")
+	}
+
+	resp, err := humiographql.ListDashboards(context.Background(), d.client, searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	respSearchDomain := resp.GetSearchDomain()
+	respDashboards := respSearchDomain.GetDashboards()
+	dashboards := make([]Dashboard, len(respDashboards))
+	for idx, dashboard := range respDashboards {
+		dashboards[idx] = mapDashboardDetails(dashboard.DashboardDetails)
+	}
+	return dashboards, nil
+}
+
+// Get returns the dashboard with the given name. Dashboard names are not unique, so it fails with a
+// ValidationError rather than picking one when several dashboards share the name.
+func (d *Dashboards) Get(searchDomainName, dashboardName string) (*Dashboard, error) {
+	dashboards, err := d.List(searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *Dashboard
+	for i := range dashboards {
+		if dashboards[i].Name != dashboardName {
+			continue
+		}
+		if match != nil {
+			return nil, ValidationError{Message: fmt.Sprintf("several dashboards in %q are named %q", searchDomainName, dashboardName)}
+		}
+		match = &dashboards[i]
+	}
+
+	if match == nil {
+		return nil, DashboardNotFound(dashboardName)
+	}
+	return match, nil
+}
+
+// Template returns the YAML template of a dashboard, in the format produced by the export in the UI.
+func (d *Dashboards) Template(searchDomainName, dashboardID string) (string, error) {
+	resp, err := humiographql.GetDashboardTemplate(context.Background(), d.client, searchDomainName, dashboardID)
+	if err != nil {
+		return "", err
+	}
+
+	respSearchDomain := resp.GetSearchDomain()
+	respDashboard := respSearchDomain.GetDashboard()
+	return respDashboard.GetTemplateYaml(), nil
+}
+
+// Create creates a dashboard from a YAML template, in the format produced by the export in the UI.
+func (d *Dashboards) Create(searchDomainName, dashboardName, template string) (*Dashboard, error) {
+	if searchDomainName == "" {
+		return nil, fmt.Errorf("searchDomainName must not be empty")
+	}
+
+	if dashboardName == "" {
+		return nil, fmt.Errorf("dashboardName must not be empty")
+	}
+
+	resp, err := humiographql.CreateDashboardFromTemplate(context.Background(), d.client, searchDomainName, dashboardName, template)
+	if err != nil {
+		return nil, err
+	}
+
+	respDashboard := resp.GetCreateDashboardFromTemplateV2()
+	dashboard := mapDashboardDetails(respDashboard.DashboardDetails)
+	return &dashboard, nil
+}
+
+func (d *Dashboards) Delete(dashboardID string) error {
+	if dashboardID == "" {
+		return fmt.Errorf("dashboardID is empty")
+	}
+
+	_, err := humiographql.DeleteDashboard(context.Background(), d.client, dashboardID)
+	return err
+}
+
+func mapDashboardDetails(dashboard humiographql.DashboardDetails) Dashboard {
+	return Dashboard{
+		ID:             dashboard.GetId(),
+		Name:           dashboard.GetName(),
+		Description:    dashboard.GetDescription(),
+		Labels:         dashboard.GetLabels(),
+		WidgetCount:    len(dashboard.GetWidgets()),
+		ParameterCount: len(dashboard.GetParameters()),
+	}
+}
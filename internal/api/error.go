@@ -2,6 +2,10 @@ package api
 
 import (
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type EntityType string
@@ -19,6 +23,11 @@ const (
 	EntityTypeAggregateAlert  EntityType = "aggregate-alert"
 	EntityTypeUser            EntityType = "user"
 	EntityTypeClusterNode     EntityType = "cluster-node"
+	EntityTypeDashboard       EntityType = "dashboard"
+	EntityTypeSavedQuery      EntityType = "saved-query"
+	EntityTypeRole            EntityType = "role"
+	EntityTypeGroup           EntityType = "group"
+	EntityTypeToken           EntityType = "token"
 )
 
 func (e EntityType) String() string {
@@ -42,6 +51,15 @@ func (e EntityNotFound) Error() string {
 	return fmt.Sprintf("%s %q not found", e.entityType.String(), e.key)
 }
 
+// As allows an EntityNotFound to be matched as a NotFoundError with errors.As.
+func (e EntityNotFound) As(target interface{}) bool {
+	if t, ok := target.(*NotFoundError); ok {
+		*t = NotFoundError{Message: e.Error()}
+		return true
+	}
+	return false
+}
+
 func SearchDomainNotFound(name string) error {
 	return EntityNotFound{
 		entityType: EntityTypeSearchDomain,
@@ -125,3 +143,189 @@ func ClusterNodeNotFound(name string) error {
 		key:        name,
 	}
 }
+
+func DashboardNotFound(name string) error {
+	return EntityNotFound{
+		entityType: EntityTypeDashboard,
+		key:        name,
+	}
+}
+
+func SavedQueryNotFound(name string) error {
+	return EntityNotFound{
+		entityType: EntityTypeSavedQuery,
+		key:        name,
+	}
+}
+
+func RoleNotFound(name string) error {
+	return EntityNotFound{
+		entityType: EntityTypeRole,
+		key:        name,
+	}
+}
+
+func GroupNotFound(name string) error {
+	return EntityNotFound{
+		entityType: EntityTypeGroup,
+		key:        name,
+	}
+}
+
+func TokenNotFound(name string) error {
+	return EntityNotFound{
+		entityType: EntityTypeToken,
+		key:        name,
+	}
+}
+
+// PermissionDeniedError is returned when the token is missing, invalid or lacks the permissions for an operation.
+type PermissionDeniedError struct {
+	Message string
+}
+
+func (e PermissionDeniedError) Error() string {
+	return e.Message
+}
+
+// NotFoundError is returned when the cluster reports that an entity does not exist.
+// An EntityNotFound can also be matched as a NotFoundError.
+type NotFoundError struct {
+	Message string
+}
+
+func (e NotFoundError) Error() string {
+	return e.Message
+}
+
+// ConflictError is returned when an entity already exists or was changed concurrently.
+type ConflictError struct {
+	Message string
+}
+
+func (e ConflictError) Error() string {
+	return e.Message
+}
+
+// ValidationError is returned when the cluster rejects the input of an operation.
+// Field holds the path of the offending input field, if the cluster reported one.
+type ValidationError struct {
+	Message string
+	Field   string
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// RateLimitedError is returned when the cluster asks the client to slow down.
+// RetryAfter is zero if the cluster did not say when to retry.
+type RateLimitedError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e RateLimitedError) Error() string {
+	return e.Message
+}
+
+// ServerError is returned when the cluster fails to handle an otherwise valid request.
+// StatusCode is zero for errors reported in a GraphQL response.
+type ServerError struct {
+	Message    string
+	StatusCode int
+}
+
+func (e ServerError) Error() string {
+	return e.Message
+}
+
+// errorFromStatus returns the typed error matching the HTTP status of resp, with the given message.
+// Statuses without a matching type result in a plain error.
+func errorFromStatus(resp *http.Response, message string) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return PermissionDeniedError{Message: message}
+	case resp.StatusCode == http.StatusNotFound:
+		return NotFoundError{Message: message}
+	case resp.StatusCode == http.StatusConflict:
+		return ConflictError{Message: message}
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity:
+		return ValidationError{Message: message}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return RateLimitedError{Message: message, RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	case resp.StatusCode >= 500:
+		return ServerError{Message: message, StatusCode: resp.StatusCode}
+	default:
+		return fmt.Errorf("%s", message)
+	}
+}
+
+func retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// errorFromGraphql returns the typed error matching the classification in the extensions of a GraphQL error,
+// or nil if the error is not classified.
+func errorFromGraphql(err *GraphqlError) error {
+	message := err.Error()
+
+	for _, key := range []string{"code", "classification", "type", "errorType"} {
+		value, ok := err.Extensions[key].(string)
+		if !ok {
+			continue
+		}
+
+		kind := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(value))
+		switch {
+		case containsAny(kind, "permission", "forbidden", "unauthorized", "unauthenticated", "accessdenied"):
+			return PermissionDeniedError{Message: message}
+		case containsAny(kind, "notfound"):
+			return NotFoundError{Message: message}
+		case containsAny(kind, "conflict", "alreadyexists", "duplicate"):
+			return ConflictError{Message: message}
+		case containsAny(kind, "validation", "invalid", "badrequest", "baduserinput"):
+			return ValidationError{Message: message, Field: graphqlErrorField(err)}
+		case containsAny(kind, "ratelimit", "toomanyrequests", "throttl"):
+			return RateLimitedError{Message: message}
+		case containsAny(kind, "internal", "server"):
+			return ServerError{Message: message}
+		}
+	}
+
+	return nil
+}
+
+// graphqlErrorField returns the input field a validation error refers to, falling back to the path of the error.
+func graphqlErrorField(err *GraphqlError) string {
+	for _, key := range []string{"field", "fieldPath", "argument"} {
+		switch value := err.Extensions[key].(type) {
+		case string:
+			return value
+		case []interface{}:
+			parts := make([]string, len(value))
+			for i, part := range value {
+				parts[i] = fmt.Sprint(part)
+			}
+			return strings.Join(parts, ".")
+		}
+	}
+	return err.Path.String()
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestErrorFromGraphql(t *testing.T) {
+	gqlErr := &GraphqlError{
+		Message:    "Invalid regex",
+		Path:       ast.Path{ast.PathName("createParserV2")},
+		Extensions: map[string]interface{}{"code": "VALIDATION_ERROR", "field": []interface{}{"input", "script"}},
+	}
+	gqlErr.Err = errorFromGraphql(gqlErr)
+
+	var validationErr ValidationError
+	if !errors.As(ErrorList{gqlErr}, &validationErr) {
+		t.Fatalf("expected a ValidationError, got %#v", gqlErr.Err)
+	}
+	if validationErr.Field != "input.script" {
+		t.Errorf("unexpected field: %q", validationErr.Field)
+	}
+
+	if err := errorFromGraphql(&GraphqlError{Message: "Something happened"}); err != nil {
+		t.Errorf("expected unclassified error to stay unclassified, got %#v", err)
+	}
+}
+
+func TestErrorFromStatus(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3"}}}
+
+	var rateLimitedErr RateLimitedError
+	if !errors.As(errorFromStatus(resp, "slow down"), &rateLimitedErr) {
+		t.Fatal("expected a RateLimitedError")
+	}
+	if rateLimitedErr.RetryAfter != 3*time.Second {
+		t.Errorf("unexpected retry after: %s", rateLimitedErr.RetryAfter)
+	}
+
+	if !errors.As(ParserNotFound("json"), &NotFoundError{}) {
+		t.Error("expected EntityNotFound to match NotFoundError")
+	}
+}
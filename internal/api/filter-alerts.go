@@ -19,6 +19,8 @@ type FilterAlert struct {
 	ThrottleTimeSeconds *int64  `yaml:"throttleTimeSeconds"`
 	ThrottleField       *string `yaml:"throttleField"`
 	OwnershipRunAsID    string  `yaml:"ownershipRunAsID"`
+	LastError           *string `yaml:"-"`
+	LastTriggered       *int64  `yaml:"-"`
 }
 
 type FilterAlerts struct {
@@ -52,6 +54,8 @@ func (fa *FilterAlerts) List(searchDomainName string) ([]FilterAlert, error) {
 			ActionNames:         actionNames,
 			Labels:              filterAlert.GetLabels(),
 			Enabled:             filterAlert.GetEnabled(),
+			LastError:           filterAlert.GetLastError(),
+			LastTriggered:       filterAlert.GetLastTriggered(),
 			ThrottleField:       filterAlert.GetThrottleField(),
 			ThrottleTimeSeconds: filterAlert.GetThrottleTimeSeconds(),
 			QueryOwnershipType:  string(queryOwnershipToQueryOwnershipType(filterAlert.GetQueryOwnership())),
@@ -61,6 +65,13 @@ func (fa *FilterAlerts) List(searchDomainName string) ([]FilterAlert, error) {
 	return filterAlerts, nil
 }
 
+// ListAll lists the filter alerts of several search domains concurrently.
+func (fa *FilterAlerts) ListAll(ctx context.Context, searchDomainNames []string, opts BulkOptions) []BulkResult[string, []FilterAlert] {
+	return Bulk(ctx, searchDomainNames, opts, func(_ context.Context, name string) ([]FilterAlert, error) {
+		return fa.List(name)
+	})
+}
+
 func (fa *FilterAlerts) Create(searchDomainName string, newFilterAlert *FilterAlert) (*FilterAlert, error) {
 	if searchDomainName == "" {
 		return nil, fmt.Errorf("searchDomainName must not be empty")
@@ -107,6 +118,8 @@ func (fa *FilterAlerts) Create(searchDomainName string, newFilterAlert *FilterAl
 		ActionNames:         actionNames,
 		Labels:              respFilterAlert.GetLabels(),
 		Enabled:             respFilterAlert.GetEnabled(),
+		LastError:           respFilterAlert.GetLastError(),
+		LastTriggered:       respFilterAlert.GetLastTriggered(),
 		ThrottleField:       respFilterAlert.ThrottleField,
 		ThrottleTimeSeconds: respFilterAlert.GetThrottleTimeSeconds(),
 		QueryOwnershipType:  string(queryOwnershipToQueryOwnershipType(respFilterAlert.GetQueryOwnership())),
@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/humio/cli/internal/api/humiographql"
 )
@@ -10,9 +12,32 @@ type Groups struct {
 	client *Client
 }
 
+// Group is a group of users. Only ID and DisplayName are set by List, the other fields are set by Get.
 type Group struct {
-	ID          string
-	DisplayName string
+	ID                string
+	DisplayName       string
+	LookupName        *string
+	Members           []GroupMember
+	ViewRoles         []GroupViewRole
+	OrganizationRoles []string
+	SystemRoles       []string
+}
+
+type GroupMember struct {
+	ID       string
+	Username string
+}
+
+// GroupViewRole is a role assigned to a group for a repository or view.
+type GroupViewRole struct {
+	View string
+	Role string
+}
+
+// GroupMembershipChanges lists the usernames added to and removed from a group when synchronizing its members.
+type GroupMembershipChanges struct {
+	Added   []string
+	Removed []string
 }
 
 func (c *Client) Groups() *Groups { return &Groups{client: c} }
@@ -35,6 +60,85 @@ func (g *Groups) List() ([]Group, error) {
 	return groups, nil
 }
 
+func (g *Groups) Get(groupName string) (*Group, error) {
+	groupID, err := g.GetGroupID(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GetByID(groupID)
+}
+
+func (g *Groups) GetByID(groupID string) (*Group, error) {
+	resp, err := humiographql.GetGroupByID(context.Background(), g.client, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	group := mapGroupDetails(resp.GetGroup().GroupDetails)
+	return &group, nil
+}
+
+func (g *Groups) GetGroupID(groupName string) (string, error) {
+	groups, err := g.List()
+	if err != nil {
+		return "", fmt.Errorf("unable to list groups: %w", err)
+	}
+
+	for _, group := range groups {
+		if group.DisplayName == groupName {
+			return group.ID, nil
+		}
+	}
+
+	return "", GroupNotFound(groupName)
+}
+
+func (g *Groups) Create(groupName string, lookupName *string) (*Group, error) {
+	if groupName == "" {
+		return nil, fmt.Errorf("groupName must not be empty")
+	}
+
+	resp, err := humiographql.CreateGroup(context.Background(), g.client, groupName, lookupName)
+	if err != nil {
+		return nil, err
+	}
+
+	respAddGroup := resp.GetAddGroup()
+	group := mapGroupDetails(respAddGroup.GetGroup().GroupDetails)
+	return &group, nil
+}
+
+func (g *Groups) Rename(groupName, newGroupName string) (*Group, error) {
+	if newGroupName == "" {
+		return nil, fmt.Errorf("newGroupName must not be empty")
+	}
+
+	groupID, err := g.GetGroupID(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := humiographql.UpdateGroup(context.Background(), g.client, groupID, &newGroupName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	respUpdateGroup := resp.GetUpdateGroup()
+	group := mapGroupDetails(respUpdateGroup.GetGroup().GroupDetails)
+	return &group, nil
+}
+
+func (g *Groups) Delete(groupName string) error {
+	groupID, err := g.GetGroupID(groupName)
+	if err != nil {
+		return err
+	}
+
+	_, err = humiographql.RemoveGroup(context.Background(), g.client, groupID)
+	return err
+}
+
 func (g *Groups) AddUserToGroup(groupID string, userID string) error {
 	_, err := humiographql.AddUserToGroup(context.Background(), g.client, groupID, userID)
 	if err != nil {
@@ -52,3 +156,116 @@ func (g *Groups) RemoveUserFromGroup(groupID string, userID string) error {
 
 	return nil
 }
+
+// SyncMembers adds and removes users so the members of a group are exactly the given usernames.
+// All usernames must belong to existing users. If dryRun is set, the changes are only computed.
+func (g *Groups) SyncMembers(groupName string, usernames []string, dryRun bool) (GroupMembershipChanges, error) {
+	var changes GroupMembershipChanges
+
+	group, err := g.Get(groupName)
+	if err != nil {
+		return changes, err
+	}
+
+	users, err := g.client.Users().List()
+	if err != nil {
+		return changes, fmt.Errorf("unable to list users: %w", err)
+	}
+	userIDs := make(map[string]string, len(users))
+	for _, user := range users {
+		userIDs[user.Username] = user.ID
+	}
+
+	changes, err = groupMembershipChanges(group.Members, usernames, userIDs)
+	if err != nil || dryRun {
+		return changes, err
+	}
+
+	current := make(map[string]string, len(group.Members))
+	for _, member := range group.Members {
+		current[member.Username] = member.ID
+	}
+
+	for _, username := range changes.Added {
+		if err := g.AddUserToGroup(group.ID, userIDs[username]); err != nil {
+			return changes, fmt.Errorf("unable to add user %q: %w", username, err)
+		}
+	}
+	for _, username := range changes.Removed {
+		if err := g.RemoveUserFromGroup(group.ID, current[username]); err != nil {
+			return changes, fmt.Errorf("unable to remove user %q: %w", username, err)
+		}
+	}
+
+	return changes, nil
+}
+
+// groupMembershipChanges returns the usernames to add to and remove from a group with the given members,
+// so its members are exactly the given usernames. userIDs maps the usernames of all existing users to their IDs.
+func groupMembershipChanges(members []GroupMember, usernames []string, userIDs map[string]string) (GroupMembershipChanges, error) {
+	var changes GroupMembershipChanges
+
+	wanted := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		if _, ok := userIDs[username]; !ok {
+			return changes, UserNotFound(username)
+		}
+		wanted[username] = true
+	}
+
+	current := make(map[string]bool, len(members))
+	for _, member := range members {
+		current[member.Username] = true
+		if !wanted[member.Username] {
+			changes.Removed = append(changes.Removed, member.Username)
+		}
+	}
+	for username := range wanted {
+		if !current[username] {
+			changes.Added = append(changes.Added, username)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+
+	return changes, nil
+}
+
+func mapGroupDetails(group humiographql.GroupDetails) Group {
+	members := make([]GroupMember, len(group.GetUsers()))
+	for idx, user := range group.GetUsers() {
+		members[idx] = GroupMember{
+			ID:       user.GetId(),
+			Username: user.GetUsername(),
+		}
+	}
+
+	viewRoles := make([]GroupViewRole, len(group.GetRoles()))
+	for idx, role := range group.GetRoles() {
+		searchDomain := role.GetSearchDomain()
+		viewRoles[idx] = GroupViewRole{
+			View: searchDomain.GetName(),
+			Role: role.GetRole().DisplayName,
+		}
+	}
+
+	organizationRoles := make([]string, len(group.GetOrganizationRoles()))
+	for idx, role := range group.GetOrganizationRoles() {
+		organizationRoles[idx] = role.GetRole().DisplayName
+	}
+
+	systemRoles := make([]string, len(group.GetSystemRoles()))
+	for idx, role := range group.GetSystemRoles() {
+		systemRoles[idx] = role.GetRole().DisplayName
+	}
+
+	return Group{
+		ID:                group.GetId(),
+		DisplayName:       group.GetDisplayName(),
+		LookupName:        group.GetLookupName(),
+		Members:           members,
+		ViewRoles:         viewRoles,
+		OrganizationRoles: organizationRoles,
+		SystemRoles:       systemRoles,
+	}
+}
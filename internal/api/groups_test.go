@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGroupMembershipChanges(t *testing.T) {
+	userIDs := map[string]string{"alice": "1", "bob": "2", "carol": "3", "dave": "4"}
+	members := []GroupMember{{ID: "1", Username: "alice"}, {ID: "2", Username: "bob"}}
+
+	tests := []struct {
+		usernames []string
+		added     []string
+		removed   []string
+	}{
+		{[]string{"alice", "bob"}, nil, nil},
+		{[]string{"bob", "alice", "bob"}, nil, nil},
+		{[]string{"dave", "alice", "carol"}, []string{"carol", "dave"}, []string{"bob"}},
+		{[]string{}, nil, []string{"alice", "bob"}},
+	}
+	for _, test := range tests {
+		changes, err := groupMembershipChanges(members, test.usernames, userIDs)
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.usernames, err)
+			continue
+		}
+		if fmt.Sprint(changes.Added) != fmt.Sprint(test.added) || fmt.Sprint(changes.Removed) != fmt.Sprint(test.removed) {
+			t.Errorf("%v: expected to add %v and remove %v, got %+v", test.usernames, test.added, test.removed, changes)
+		}
+	}
+
+	_, err := groupMembershipChanges(members, []string{"alice", "mallory"}, userIDs)
+	if expected := UserNotFound("mallory"); err == nil || err.Error() != expected.Error() {
+		t.Errorf("expected error %v for an unknown user, got %v", expected, err)
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"net"
 	"net/http"
 	"time"
@@ -12,8 +13,9 @@ import (
 // We use the approach described here: https://github.com/shurcooL/graphql/issues/28#issuecomment-464713908
 
 type headerTransport struct {
-	base    http.RoundTripper
-	headers map[string]string
+	base          http.RoundTripper
+	headers       map[string]string
+	tokenProvider TokenProvider
 }
 
 func NewHttpTransport(config Config) *http.Transport {
@@ -79,8 +81,9 @@ func NewHttpTransport(config Config) *http.Transport {
 func (c *Client) newHTTPClientWithHeaders(headers map[string]string) *http.Client {
 	return &http.Client{
 		Transport: &headerTransport{
-			base:    c.httpTransport,
-			headers: headers,
+			base:          c.roundTripper,
+			headers:       headers,
+			tokenProvider: c.config.TokenProvider,
 		},
 		Timeout: 30 * time.Second,
 	}
@@ -91,7 +94,46 @@ func (h *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	for key, val := range h.headers {
 		req2.Header.Set(key, val)
 	}
-	return h.base.RoundTrip(req2)
+	if h.tokenProvider == nil {
+		return h.base.RoundTrip(req2)
+	}
+
+	if err := h.setAuthorization(req2); err != nil {
+		return nil, err
+	}
+	resp, err := h.base.RoundTrip(req2)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The token was rejected, so fetch a new one and retry once if the request body can be replayed.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	h.tokenProvider.Invalidate()
+
+	req3 := CloneRequest(req2)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req3.Body = body
+	}
+	if err := h.setAuthorization(req3); err != nil {
+		return nil, err
+	}
+	return h.base.RoundTrip(req3)
+}
+
+func (h *headerTransport) setAuthorization(req *http.Request) error {
+	token, err := h.tokenProvider.Token()
+	if err != nil {
+		return fmt.Errorf("unable to obtain API token: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return nil
 }
 
 // CloneRequest and CloneHeader copied from https://github.com/kubernetes/apimachinery/blob/a76b7114b20a2e56fd698bba815b1e2c82ec4bff/pkg/util/net/http.go#L469-L491
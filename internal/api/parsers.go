@@ -87,6 +87,35 @@ func (p *Parsers) Delete(repositoryName string, parserName string) error {
 	return err
 }
 
+// Clone creates a copy of an existing parser, including built-in parsers, under a new name in the same repository.
+func (p *Parsers) Clone(repositoryName string, parserName string, newParserName string) (*ParserListItem, error) {
+	parserList, err := p.List(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	parserID := ""
+	for i := range parserList {
+		if parserList[i].Name == parserName {
+			parserID = parserList[i].ID
+			break
+		}
+	}
+	if parserID == "" {
+		return nil, ParserNotFound(parserName)
+	}
+
+	resp, err := humiographql.CloneParser(context.Background(), p.client, repositoryName, parserID, newParserName)
+	if err != nil {
+		return nil, err
+	}
+
+	respParser := resp.GetCloneParser()
+	return &ParserListItem{
+		ID:   respParser.GetId(),
+		Name: respParser.GetName(),
+	}, nil
+}
+
 func (p *Parsers) Add(repositoryName string, newParser *Parser, allowOverwritingExistingParser bool) (*Parser, error) {
 	if newParser == nil {
 		return nil, fmt.Errorf("newFilterAlert must not be nil")
@@ -142,30 +171,7 @@ func (p *Parsers) Add(repositoryName string, newParser *Parser, allowOverwriting
 		}, nil
 	}
 
-	testCasesInput := make([]humiographql.ParserTestCaseInput, len(newParser.TestCases))
-	for j, pa := range newParser.TestCases {
-		parserTestCaseAssertionsForOutputInput := make([]humiographql.ParserTestCaseAssertionsForOutputInput, len(pa.Assertions))
-		for i := range pa.Assertions {
-			fieldsHaveValuesInput := make([]humiographql.FieldHasValueInput, len(pa.Assertions[i].FieldsHaveValues))
-			for field, value := range pa.Assertions[i].FieldsHaveValues {
-				fieldsHaveValuesInput[i] = humiographql.FieldHasValueInput{
-					FieldName:     field,
-					ExpectedValue: value,
-				}
-			}
-			parserTestCaseAssertionsForOutputInput[i] = humiographql.ParserTestCaseAssertionsForOutputInput{
-				OutputEventIndex: pa.Assertions[i].OutputEventIndex,
-				Assertions: humiographql.ParserTestCaseOutputAssertionsInput{
-					FieldsNotPresent: pa.Assertions[i].FieldsNotPresent,
-					FieldsHaveValues: fieldsHaveValuesInput,
-				},
-			}
-		}
-		testCasesInput[j] = humiographql.ParserTestCaseInput{
-			Event:            humiographql.ParserTestEventInput{RawString: pa.Event.RawString},
-			OutputAssertions: parserTestCaseAssertionsForOutputInput,
-		}
-	}
+	testCasesInput := parserTestCaseInputs(newParser.TestCases)
 	resp, err := humiographql.CreateParser(
 		context.Background(),
 		p.client,
@@ -193,13 +199,13 @@ func (p *Parsers) Add(repositoryName string, newParser *Parser, allowOverwriting
 			fieldHaveValue := respAssertion.GetFieldsHaveValues()
 			fieldsHaveValues := map[string]string{}
 			for _, field := range fieldHaveValue {
-				fieldsHaveValues[field.GetFieldName()] = field.GetFieldName()
+				fieldsHaveValues[field.GetFieldName()] = field.GetExpectedValue()
 			}
 
 			assertions[j] = ParserTestCaseAssertions{
 				OutputEventIndex: assertion.GetOutputEventIndex(),
 				FieldsNotPresent: respAssertion.FieldsNotPresent,
-				FieldsHaveValues: nil,
+				FieldsHaveValues: fieldsHaveValues,
 			}
 		}
 		testCases[idx] = ParserTestCase{
@@ -297,13 +303,13 @@ func (p *Parsers) Get(repositoryName string, parserName string) (*Parser, error)
 			fieldHaveValue := respAssertion.GetFieldsHaveValues()
 			fieldsHaveValues := map[string]string{}
 			for _, field := range fieldHaveValue {
-				fieldsHaveValues[field.GetFieldName()] = field.GetFieldName()
+				fieldsHaveValues[field.GetFieldName()] = field.GetExpectedValue()
 			}
 
 			assertions[j] = ParserTestCaseAssertions{
 				OutputEventIndex: assertion.GetOutputEventIndex(),
 				FieldsNotPresent: respAssertion.FieldsNotPresent,
-				FieldsHaveValues: nil,
+				FieldsHaveValues: fieldsHaveValues,
 			}
 		}
 		testCases[idx] = ParserTestCase{
@@ -331,3 +337,140 @@ func (p *Parsers) Export(repositoryName string, parserName string) (string, erro
 	respRepo := resp.GetRepository()
 	return respRepo.GetParser().GetYamlTemplate(), err
 }
+
+func parserTestCaseInputs(testCases []ParserTestCase) []humiographql.ParserTestCaseInput {
+	testCasesInput := make([]humiographql.ParserTestCaseInput, len(testCases))
+	for j, pa := range testCases {
+		parserTestCaseAssertionsForOutputInput := make([]humiographql.ParserTestCaseAssertionsForOutputInput, len(pa.Assertions))
+		for i := range pa.Assertions {
+			fieldsHaveValuesInput := make([]humiographql.FieldHasValueInput, 0, len(pa.Assertions[i].FieldsHaveValues))
+			for field, value := range pa.Assertions[i].FieldsHaveValues {
+				fieldsHaveValuesInput = append(fieldsHaveValuesInput, humiographql.FieldHasValueInput{
+					FieldName:     field,
+					ExpectedValue: value,
+				})
+			}
+			parserTestCaseAssertionsForOutputInput[i] = humiographql.ParserTestCaseAssertionsForOutputInput{
+				OutputEventIndex: pa.Assertions[i].OutputEventIndex,
+				Assertions: humiographql.ParserTestCaseOutputAssertionsInput{
+					FieldsNotPresent: pa.Assertions[i].FieldsNotPresent,
+					FieldsHaveValues: fieldsHaveValuesInput,
+				},
+			}
+		}
+		testCasesInput[j] = humiographql.ParserTestCaseInput{
+			Event:            humiographql.ParserTestEventInput{RawString: pa.Event.RawString},
+			OutputAssertions: parserTestCaseAssertionsForOutputInput,
+		}
+	}
+	return testCasesInput
+}
+
+// ParserTestCaseResult is the outcome of running a parser on the event of a test case.
+type ParserTestCaseResult struct {
+	RawString    string
+	OutputEvents []map[string]string
+	Failures     []ParserTestFailure
+}
+
+func (r ParserTestCaseResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// ParserTestFailure describes why an output event of a test case did not pass.
+// Expected and Actual are set for failures on field values.
+type ParserTestFailure struct {
+	OutputEventIndex int
+	Field            string
+	Expected         *string
+	Actual           *string
+	Message          string
+}
+
+// Test runs the parser script on its test cases without installing the parser, and returns a result for each test case.
+// If the script cannot run, a ValidationError is returned.
+func (p *Parsers) Test(repositoryName string, parser *Parser) ([]ParserTestCaseResult, error) {
+	resp, err := humiographql.TestParser(
+		context.Background(),
+		p.client,
+		repositoryName,
+		parser.Name,
+		parser.Script,
+		parserTestCaseInputs(parser.TestCases),
+		emptyIfNil(parser.FieldsToTag),
+		emptyIfNil(parser.FieldsToBeRemovedBeforeParsing),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var completed *humiographql.TestParserTestParserV2ParserTestRunCompleted
+	switch v := resp.GetTestParserV2().(type) {
+	case *humiographql.TestParserTestParserV2ParserTestRunAborted:
+		return nil, ValidationError{Message: v.GetErrorMessage()}
+	case *humiographql.TestParserTestParserV2ParserTestRunCompleted:
+		completed = v
+	default:
+		return nil, fmt.Errorf("unexpected parser test result %T", v)
+	}
+
+	results := make([]ParserTestCaseResult, len(completed.GetResults()))
+	for idx, respResult := range completed.GetResults() {
+		result := ParserTestCaseResult{}
+		if idx < len(parser.TestCases) {
+			result.RawString = parser.TestCases[idx].Event.RawString
+		}
+
+		for _, outputEvent := range respResult.GetOutputEvents() {
+			fields := make(map[string]string, len(outputEvent.GetFields()))
+			for _, field := range outputEvent.GetFields() {
+				fields[field.GetFieldName()] = field.GetValue()
+			}
+			result.OutputEvents = append(result.OutputEvents, fields)
+		}
+
+		for _, outputFailure := range respResult.GetOutputFailures() {
+			index := outputFailure.GetOutputEventIndex()
+			failures := outputFailure.GetFailures()
+
+			for _, parsingError := range failures.GetParsingErrors() {
+				result.Failures = append(result.Failures, ParserTestFailure{OutputEventIndex: index, Message: "parsing error: " + parsingError})
+			}
+			for _, assertionFailure := range failures.GetAssertionFailuresOnFields() {
+				failure := ParserTestFailure{OutputEventIndex: index}
+				switch f := assertionFailure.(type) {
+				case *humiographql.TestParserTestParserV2ParserTestRunCompletedResultsParserTestCaseResultOutputFailuresParserTestCaseFailuresForOutputFailuresParserTestCaseOutputFailuresAssertionFailuresOnFieldsFieldUnexpectedlyPresent:
+					actual := f.GetActualValue()
+					failure.Field, failure.Actual, failure.Message = f.GetFieldName(), &actual, "field is present, but was expected to be absent"
+				case *humiographql.TestParserTestParserV2ParserTestRunCompletedResultsParserTestCaseResultOutputFailuresParserTestCaseFailuresForOutputFailuresParserTestCaseOutputFailuresAssertionFailuresOnFieldsFieldHadUnexpectedValue:
+					expected := f.GetExpectedValue()
+					failure.Field, failure.Expected, failure.Actual, failure.Message = f.GetFieldName(), &expected, f.GetUnexpectedValue(), "field has an unexpected value"
+				case *humiographql.TestParserTestParserV2ParserTestRunCompletedResultsParserTestCaseResultOutputFailuresParserTestCaseFailuresForOutputFailuresParserTestCaseOutputFailuresAssertionFailuresOnFieldsFieldHadConflictingAssertions:
+					failure.Field, failure.Message = f.GetFieldName(), "field has conflicting assertions"
+				case *humiographql.TestParserTestParserV2ParserTestRunCompletedResultsParserTestCaseResultOutputFailuresParserTestCaseFailuresForOutputFailuresParserTestCaseOutputFailuresAssertionFailuresOnFieldsAssertionOnFieldWasOrphaned:
+					failure.Field, failure.Message = f.GetFieldName(), "assertion refers to an output event which was not produced"
+				default:
+					failure.Message = "assertion failed"
+				}
+				result.Failures = append(result.Failures, failure)
+			}
+			for _, field := range failures.GetFalselyTaggedFields() {
+				result.Failures = append(result.Failures, ParserTestFailure{OutputEventIndex: index, Field: field, Message: "field name starts with # but the field is not a tag"})
+			}
+			for _, arrayWithGap := range failures.GetArraysWithGaps() {
+				result.Failures = append(result.Failures, ParserTestFailure{OutputEventIndex: index, Field: arrayWithGap.GetLastValidPrefix(), Message: "array has gaps after this element"})
+			}
+		}
+
+		results[idx] = result
+	}
+
+	return results, nil
+}
+
+func emptyIfNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
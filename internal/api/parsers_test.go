@@ -0,0 +1,32 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestParserTestCaseInputs(t *testing.T) {
+	inputs := parserTestCaseInputs([]ParserTestCase{{
+		Event: ParserTestEvent{RawString: "status=200 method=GET"},
+		Assertions: []ParserTestCaseAssertions{{
+			OutputEventIndex: 0,
+			FieldsNotPresent: []string{"error"},
+			FieldsHaveValues: map[string]string{"status": "200", "method": "GET"},
+		}},
+	}})
+
+	if len(inputs) != 1 || len(inputs[0].OutputAssertions) != 1 {
+		t.Fatalf("expected one test case with one output assertion, got %+v", inputs)
+	}
+
+	// Every field assertion must be sent, not only the last one written for each output event.
+	var fields []string
+	for _, field := range inputs[0].OutputAssertions[0].Assertions.FieldsHaveValues {
+		fields = append(fields, field.FieldName+"="+field.ExpectedValue)
+	}
+	sort.Strings(fields)
+	if expected := "[method=GET status=200]"; fmt.Sprint(fields) != expected {
+		t.Errorf("expected field assertions %s, got %v", expected, fields)
+	}
+}
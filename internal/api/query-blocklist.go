@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/humio/cli/internal/api/humiographql"
+)
+
+type QueryBlocklist struct {
+	client *Client
+}
+
+// BlockedQuery is a pattern preventing matching queries from running. Patterns apply to the whole organization
+// unless View is set, or to the whole cluster if ClusterWide is set.
+type BlockedQuery struct {
+	ID          string     `yaml:"-"`
+	Pattern     string     `yaml:"pattern"`
+	Type        string     `yaml:"type"`
+	View        *string    `yaml:"view,omitempty"`
+	ClusterWide bool       `yaml:"clusterWide,omitempty"`
+	ExpiresAt   *time.Time `yaml:"-"`
+}
+
+func (c *Client) QueryBlocklist() *QueryBlocklist { return &QueryBlocklist{client: c} }
+
+// List returns the blocked query patterns of the organization, and the cluster wide patterns if clusterWide is set.
+func (q *QueryBlocklist) List(clusterWide bool) ([]BlockedQuery, error) {
+	resp, err := humiographql.ListBlockedQueries(context.Background(), q.client, &clusterWide)
+	if err != nil {
+		return nil, err
+	}
+
+	respBlockedQueries := resp.GetBlockedQueries()
+	blockedQueries := make([]BlockedQuery, len(respBlockedQueries))
+	for idx, blockedQuery := range respBlockedQueries {
+		blockedQueries[idx] = mapBlockedQueryDetails(blockedQuery.BlockedQueryDetails)
+	}
+
+	return blockedQueries, nil
+}
+
+func (q *QueryBlocklist) Add(blockedQuery BlockedQuery) (*BlockedQuery, error) {
+	matcherType, err := blockedQueryMatcherType(blockedQuery.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterWide *bool
+	if blockedQuery.ClusterWide {
+		clusterWide = &blockedQuery.ClusterWide
+	}
+
+	resp, err := humiographql.AddToBlocklist(context.Background(), q.client, blockedQuery.Pattern, matcherType, blockedQuery.View, clusterWide)
+	if err != nil {
+		return nil, err
+	}
+
+	respBlockedQueries := resp.GetAddToBlocklist()
+	for _, respBlockedQuery := range respBlockedQueries {
+		added := mapBlockedQueryDetails(respBlockedQuery.BlockedQueryDetails)
+		if added.Pattern == blockedQuery.Pattern && added.Type == string(matcherType) {
+			return &added, nil
+		}
+	}
+
+	return nil, fmt.Errorf("pattern %q was not found in the blocklist after adding it", blockedQuery.Pattern)
+}
+
+func (q *QueryBlocklist) Remove(id string) error {
+	_, err := humiographql.RemoveFromBlocklist(context.Background(), q.client, id)
+	return err
+}
+
+func blockedQueryMatcherType(matcherType string) (humiographql.BlockedQueryMatcherType, error) {
+	switch strings.ToUpper(matcherType) {
+	case "", string(humiographql.BlockedQueryMatcherTypeExact):
+		return humiographql.BlockedQueryMatcherTypeExact, nil
+	case string(humiographql.BlockedQueryMatcherTypeRegex):
+		return humiographql.BlockedQueryMatcherTypeRegex, nil
+	default:
+		return "", fmt.Errorf("unsupported pattern type %q, must be exact or regex", matcherType)
+	}
+}
+
+func mapBlockedQueryDetails(blockedQuery humiographql.BlockedQueryDetails) BlockedQuery {
+	var view *string
+	if blockedQuery.GetView() != nil {
+		name := blockedQuery.GetView().GetName()
+		view = &name
+	}
+
+	return BlockedQuery{
+		ID:          blockedQuery.GetId(),
+		Pattern:     blockedQuery.GetPattern(),
+		Type:        string(blockedQuery.GetType()),
+		View:        view,
+		ClusterWide: !blockedQuery.GetLimitedToOrganization(),
+		ExpiresAt:   blockedQuery.GetExpiresAt(),
+	}
+}
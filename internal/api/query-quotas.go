@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+
+	"github.com/humio/cli/internal/api/humiographql"
+)
+
+type QueryQuotas struct {
+	client *Client
+}
+
+// QueryQuotaSetting limits the queries run in an interval such as PerHour, measured as QueryCount, StaticCost or LiveCost.
+// A nil Value means there is no limit.
+type QueryQuotaSetting struct {
+	Interval        string `yaml:"interval"`
+	MeasurementKind string `yaml:"measurementKind"`
+	Value           *int64 `yaml:"value,omitempty"`
+	UserSpecified   bool   `yaml:"-"`
+}
+
+type QueryQuotaUserSettings struct {
+	Username string
+	Settings []QueryQuotaSetting
+}
+
+func (c *Client) QueryQuotas() *QueryQuotas { return &QueryQuotas{client: c} }
+
+// Defaults returns the quota settings used for users without settings of their own.
+func (q *QueryQuotas) Defaults() ([]QueryQuotaSetting, error) {
+	resp, err := humiographql.GetQueryQuotaDefaultSettings(context.Background(), q.client)
+	if err != nil {
+		return nil, err
+	}
+
+	respSettings := resp.GetQueryQuotaDefaultSettings()
+	settings := make([]QueryQuotaSetting, len(respSettings))
+	for idx, setting := range respSettings {
+		settings[idx] = mapQueryQuotaSetting(setting.QueryQuotaIntervalSettingDetails)
+	}
+
+	return settings, nil
+}
+
+// List returns the quota settings of users. If username is empty, all users with settings of their own are returned.
+func (q *QueryQuotas) List(username string) ([]QueryQuotaUserSettings, error) {
+	var usernamePtr *string
+	if username != "" {
+		usernamePtr = &username
+	}
+
+	resp, err := humiographql.ListQueryQuotaUserSettings(context.Background(), q.client, usernamePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	respUsers := resp.GetQueryQuotaUserSettings()
+	users := make([]QueryQuotaUserSettings, len(respUsers))
+	for idx, user := range respUsers {
+		settings := make([]QueryQuotaSetting, len(user.GetSettings()))
+		for i, setting := range user.GetSettings() {
+			settings[i] = mapQueryQuotaSetting(setting.QueryQuotaIntervalSettingDetails)
+		}
+		users[idx] = QueryQuotaUserSettings{
+			Username: user.GetUsername(),
+			Settings: settings,
+		}
+	}
+
+	return users, nil
+}
+
+// SetDefaults adds or updates default quota settings. Settings for other intervals and measurements are kept.
+func (q *QueryQuotas) SetDefaults(settings []QueryQuotaSetting) error {
+	_, err := humiographql.SetQueryQuotaDefaultSettings(context.Background(), q.client, queryQuotaSettingInputs(settings))
+	return err
+}
+
+// SetForUser adds or updates quota settings for a user. Settings for other intervals and measurements are kept.
+func (q *QueryQuotas) SetForUser(username string, settings []QueryQuotaSetting) error {
+	_, err := humiographql.SetQueryQuotaUserSettings(context.Background(), q.client, username, queryQuotaSettingInputs(settings))
+	return err
+}
+
+// RemoveForUser removes the quota settings of a user, so the defaults apply to them.
+func (q *QueryQuotas) RemoveForUser(username string) error {
+	_, err := humiographql.RemoveQueryQuotaUserSettings(context.Background(), q.client, username)
+	return err
+}
+
+func queryQuotaSettingInputs(settings []QueryQuotaSetting) []humiographql.QueryQuotaIntervalSettingInput {
+	inputs := make([]humiographql.QueryQuotaIntervalSettingInput, len(settings))
+	for idx, setting := range settings {
+		valueKind := humiographql.QueryQuotaIntervalSettingKindLimited
+		if setting.Value == nil {
+			valueKind = humiographql.QueryQuotaIntervalSettingKindLimitless
+		}
+		inputs[idx] = humiographql.QueryQuotaIntervalSettingInput{
+			Interval:        humiographql.QueryQuotaInterval(setting.Interval),
+			MeasurementKind: humiographql.QueryQuotaMeasurementKind(setting.MeasurementKind),
+			Value:           setting.Value,
+			ValueKind:       valueKind,
+		}
+	}
+	return inputs
+}
+
+func mapQueryQuotaSetting(setting humiographql.QueryQuotaIntervalSettingDetails) QueryQuotaSetting {
+	var value *int64
+	if setting.GetValueKind() == humiographql.QueryQuotaIntervalSettingKindLimited {
+		value = setting.GetValue()
+	}
+
+	return QueryQuotaSetting{
+		Interval:        string(setting.GetInterval()),
+		MeasurementKind: string(setting.GetMeasurementKind()),
+		Value:           value,
+		UserSpecified:   setting.GetSource() == humiographql.QueryQuotaIntervalSettingSourceUserspecified,
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"regexp"
+)
+
+// SearchDomainReference is a trigger or action which mentions a repository or view by name,
+// and which may therefore stop working as intended when that repository or view is renamed.
+type SearchDomainReference struct {
+	SearchDomain string
+	Kind         string
+	Name         string
+}
+
+// FindReferences returns the alerts, filter alerts, aggregate alerts, scheduled searches and actions
+// in searchDomainName whose queries or message templates mention name.
+func (s *SearchDomains) FindReferences(searchDomainName string, name string) ([]SearchDomainReference, error) {
+	pattern := namePattern(name)
+	var references []SearchDomainReference
+	add := func(kind, triggerName string, texts ...string) {
+		for _, text := range texts {
+			if pattern.MatchString(text) {
+				references = append(references, SearchDomainReference{SearchDomain: searchDomainName, Kind: kind, Name: triggerName})
+				return
+			}
+		}
+	}
+
+	alerts, err := s.client.Alerts().List(searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range alerts {
+		add("alert", alert.Name, alert.QueryString)
+	}
+
+	filterAlerts, err := s.client.FilterAlerts().List(searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, filterAlert := range filterAlerts {
+		add("filter alert", filterAlert.Name, filterAlert.QueryString)
+	}
+
+	aggregateAlerts, err := s.client.AggregateAlerts().List(searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, aggregateAlert := range aggregateAlerts {
+		add("aggregate alert", aggregateAlert.Name, aggregateAlert.QueryString)
+	}
+
+	scheduledSearches, err := s.client.ScheduledSearches().List(searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, scheduledSearch := range scheduledSearches {
+		add("scheduled search", scheduledSearch.Name, scheduledSearch.QueryString)
+	}
+
+	actions, err := s.client.Actions().List(searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range actions {
+		add("action", action.Name, actionTemplates(action)...)
+	}
+
+	return references, nil
+}
+
+// namePattern matches name as a whole word, so that renaming "logs" does not flag queries mentioning "weblogs".
+func namePattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^\w.-])` + regexp.QuoteMeta(name) + `($|[^\w.-])`)
+}
+
+// actionTemplates returns the URLs and message templates of an action.
+func actionTemplates(action Action) []string {
+	var templates []string
+	if action.EmailAction.SubjectTemplate != nil {
+		templates = append(templates, *action.EmailAction.SubjectTemplate)
+	}
+	if action.EmailAction.BodyTemplate != nil {
+		templates = append(templates, *action.EmailAction.BodyTemplate)
+	}
+	for _, field := range append(action.SlackAction.Fields, action.SlackPostMessageAction.Fields...) {
+		templates = append(templates, field.Value)
+	}
+	for _, header := range action.WebhookAction.Headers {
+		templates = append(templates, header.Value)
+	}
+	return append(templates,
+		action.SlackAction.Url,
+		action.UploadFileAction.FileName,
+		action.WebhookAction.Url,
+		action.WebhookAction.BodyTemplate,
+	)
+}
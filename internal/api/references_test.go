@@ -0,0 +1,25 @@
+package api
+
+import "testing"
+
+func TestNamePattern(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"logs", true},
+		{"#repo=logs | count()", true},
+		{`readFile("logs")`, true},
+		{"#repo=weblogs", false},
+		{"#repo=logs-archive", false},
+		{"#repo=logs_old", false},
+		{"#repo=logs.old", false},
+	}
+
+	pattern := namePattern("logs")
+	for _, test := range tests {
+		if actual := pattern.MatchString(test.text); actual != test.expected {
+			t.Errorf("matching %q: expected %v, got %v", test.text, test.expected, actual)
+		}
+	}
+}
@@ -12,11 +12,12 @@ type Roles struct {
 }
 
 type Role struct {
-	ID                string
-	DisplayName       string
-	ViewPermissions   []string
-	SystemPermissions []string
-	OrgPermissions    []string
+	ID                string   `yaml:"-"`
+	DisplayName       string   `yaml:"name"`
+	Description       *string  `yaml:"description,omitempty"`
+	ViewPermissions   []string `yaml:"viewPermissions"`
+	SystemPermissions []string `yaml:"systemPermissions"`
+	OrgPermissions    []string `yaml:"organizationPermissions"`
 }
 
 func (c *Client) Roles() *Roles { return &Roles{client: c} }
@@ -30,31 +31,7 @@ func (r *Roles) List() ([]Role, error) {
 	respRoles := resp.GetRoles()
 	roles := make([]Role, len(respRoles))
 	for idx, role := range respRoles {
-		respViewPermissions := role.GetViewPermissions()
-		viewPermissions := make([]string, len(respViewPermissions))
-		for k, perm := range respViewPermissions {
-			viewPermissions[k] = string(perm)
-		}
-
-		respOrgPermissions := role.GetOrganizationPermissions()
-		orgPermissions := make([]string, len(respOrgPermissions))
-		for k, perm := range respOrgPermissions {
-			orgPermissions[k] = string(perm)
-		}
-
-		respSystemPermissions := role.GetSystemPermissions()
-		systemPermissions := make([]string, len(respSystemPermissions))
-		for k, perm := range respSystemPermissions {
-			systemPermissions[k] = string(perm)
-		}
-
-		roles[idx] = Role{
-			ID:                role.GetId(),
-			DisplayName:       role.GetDisplayName(),
-			ViewPermissions:   viewPermissions,
-			OrgPermissions:    orgPermissions,
-			SystemPermissions: systemPermissions,
-		}
+		roles[idx] = mapRoleDetails(role.RoleDetails)
 	}
 
 	return roles, nil
@@ -62,40 +39,19 @@ func (r *Roles) List() ([]Role, error) {
 
 func (r *Roles) Get(rolename string) (*Role, error) {
 	roleId, err := r.GetRoleID(rolename)
-	if roleId == "" || err != nil {
-		return nil, fmt.Errorf("unable to get role id")
-	}
-
-	resp, err := humiographql.GetRoleByID(context.Background(), r.client, roleId)
 	if err != nil {
 		return nil, err
 	}
-	role := resp.GetRole()
-	respViewPermissions := role.GetViewPermissions()
-	viewPermissions := make([]string, len(respViewPermissions))
-	for k, perm := range respViewPermissions {
-		viewPermissions[k] = string(perm)
+	if roleId == "" {
+		return nil, RoleNotFound(rolename)
 	}
 
-	respOrgPermissions := role.GetOrganizationPermissions()
-	orgPermissions := make([]string, len(respOrgPermissions))
-	for k, perm := range respOrgPermissions {
-		orgPermissions[k] = string(perm)
-	}
-
-	respSystemPermissions := role.GetSystemPermissions()
-	systemPermissions := make([]string, len(respSystemPermissions))
-	for k, perm := range respSystemPermissions {
-		systemPermissions[k] = string(perm)
+	resp, err := humiographql.GetRoleByID(context.Background(), r.client, roleId)
+	if err != nil {
+		return nil, err
 	}
-
-	return &Role{
-		ID:                role.GetId(),
-		DisplayName:       role.GetDisplayName(),
-		ViewPermissions:   viewPermissions,
-		OrgPermissions:    orgPermissions,
-		SystemPermissions: systemPermissions,
-	}, nil
+	role := mapRoleDetails(resp.GetRole().RoleDetails)
+	return &role, nil
 }
 
 func (r *Roles) GetRoleID(rolename string) (string, error) {
@@ -113,3 +69,152 @@ func (r *Roles) GetRoleID(rolename string) (string, error) {
 
 	return roleId, nil
 }
+
+func (r *Roles) Create(newRole *Role) (*Role, error) {
+	if newRole == nil {
+		return nil, fmt.Errorf("newRole must not be nil")
+	}
+
+	resp, err := humiographql.CreateRole(
+		context.Background(),
+		r.client,
+		newRole.DisplayName,
+		toPermissions[humiographql.Permission](newRole.ViewPermissions),
+		toPermissions[humiographql.OrganizationPermission](newRole.OrgPermissions),
+		toPermissions[humiographql.SystemPermission](newRole.SystemPermissions),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	respCreateRole := resp.GetCreateRole()
+	role := mapRoleDetails(respCreateRole.GetRole().RoleDetails)
+	if newRole.Description == nil {
+		return &role, nil
+	}
+
+	// Roles cannot be created with a description, so it is set by updating the new role.
+	updateResp, err := humiographql.UpdateRole(
+		context.Background(),
+		r.client,
+		role.ID,
+		newRole.DisplayName,
+		newRole.Description,
+		toPermissions[humiographql.Permission](newRole.ViewPermissions),
+		toPermissions[humiographql.OrganizationPermission](newRole.OrgPermissions),
+		toPermissions[humiographql.SystemPermission](newRole.SystemPermissions),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("role %q was created, but setting its description failed: %w", newRole.DisplayName, err)
+	}
+
+	respUpdateRole := updateResp.GetUpdateRole()
+	role = mapRoleDetails(respUpdateRole.GetRole().RoleDetails)
+	return &role, nil
+}
+
+// Update replaces the name, description and permissions of the role named rolename with those of updatedRole.
+func (r *Roles) Update(rolename string, updatedRole *Role) (*Role, error) {
+	if updatedRole == nil {
+		return nil, fmt.Errorf("updatedRole must not be nil")
+	}
+
+	roleId, err := r.GetRoleID(rolename)
+	if err != nil {
+		return nil, err
+	}
+	if roleId == "" {
+		return nil, RoleNotFound(rolename)
+	}
+
+	resp, err := humiographql.UpdateRole(
+		context.Background(),
+		r.client,
+		roleId,
+		updatedRole.DisplayName,
+		updatedRole.Description,
+		toPermissions[humiographql.Permission](updatedRole.ViewPermissions),
+		toPermissions[humiographql.OrganizationPermission](updatedRole.OrgPermissions),
+		toPermissions[humiographql.SystemPermission](updatedRole.SystemPermissions),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	respUpdateRole := resp.GetUpdateRole()
+	role := mapRoleDetails(respUpdateRole.GetRole().RoleDetails)
+	return &role, nil
+}
+
+func (r *Roles) Delete(rolename string) error {
+	roleId, err := r.GetRoleID(rolename)
+	if err != nil {
+		return err
+	}
+	if roleId == "" {
+		return RoleNotFound(rolename)
+	}
+
+	_, err = humiographql.DeleteRoleByID(context.Background(), r.client, roleId)
+	return err
+}
+
+// AssignToGroupForView gives the members of a group the view permissions of a role in a repository or view.
+func (r *Roles) AssignToGroupForView(roleID, groupID, viewID string) error {
+	_, err := humiographql.AssignRoleToGroup(context.Background(), r.client, roleID, groupID, viewID)
+	return err
+}
+
+func (r *Roles) UnassignFromGroupForView(roleID, groupID, viewID string) error {
+	_, err := humiographql.UnassignRoleFromGroup(context.Background(), r.client, roleID, groupID, viewID)
+	return err
+}
+
+// AssignToGroupForOrganization gives the members of a group the organization permissions of a role.
+func (r *Roles) AssignToGroupForOrganization(roleID, groupID string) error {
+	_, err := humiographql.AssignOrganizationRoleToGroup(context.Background(), r.client, roleID, groupID)
+	return err
+}
+
+func (r *Roles) UnassignFromGroupForOrganization(roleID, groupID string) error {
+	_, err := humiographql.UnassignOrganizationRoleFromGroup(context.Background(), r.client, roleID, groupID)
+	return err
+}
+
+// AssignToGroupForSystem gives the members of a group the system permissions of a role.
+func (r *Roles) AssignToGroupForSystem(roleID, groupID string) error {
+	_, err := humiographql.AssignSystemRoleToGroup(context.Background(), r.client, roleID, groupID)
+	return err
+}
+
+func (r *Roles) UnassignFromGroupForSystem(roleID, groupID string) error {
+	_, err := humiographql.UnassignSystemRoleFromGroup(context.Background(), r.client, roleID, groupID)
+	return err
+}
+
+func mapRoleDetails(role humiographql.RoleDetails) Role {
+	return Role{
+		ID:                role.GetId(),
+		DisplayName:       role.GetDisplayName(),
+		Description:       role.GetDescription(),
+		ViewPermissions:   fromPermissions(role.GetViewPermissions()),
+		OrgPermissions:    fromPermissions(role.GetOrganizationPermissions()),
+		SystemPermissions: fromPermissions(role.GetSystemPermissions()),
+	}
+}
+
+func fromPermissions[P ~string](permissions []P) []string {
+	result := make([]string, len(permissions))
+	for k, perm := range permissions {
+		result[k] = string(perm)
+	}
+	return result
+}
+
+func toPermissions[P ~string](permissions []string) []P {
+	result := make([]P, len(permissions))
+	for k, perm := range permissions {
+		result[k] = P(perm)
+	}
+	return result
+}
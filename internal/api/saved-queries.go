@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/humio/cli/internal/api/humiographql"
+)
+
+type SavedQuery struct {
+	ID          string                 `yaml:"-"`
+	Name        string                 `yaml:"name"`
+	Description *string                `yaml:"description,omitempty"`
+	QueryString string                 `yaml:"queryString"`
+	Start       string                 `yaml:"start"`
+	End         string                 `yaml:"end"`
+	IsLive      bool                   `yaml:"isLive"`
+	WidgetType  string                 `yaml:"widgetType"`
+	Options     map[string]interface{} `yaml:"options,omitempty"`
+}
+
+type SavedQueries struct {
+	client *Client
+}
+
+func (c *Client) SavedQueries() *SavedQueries { return &SavedQueries{client: c} }
+
+func (s *SavedQueries) List(searchDomainName string) ([]SavedQuery, error) {
+	if searchDomainName == "" {
+		return nil, fmt.Errorf("searchDomainName must not be empty")
+	}
+
+	resp, err := humiographql.ListSavedQueries(context.Background(), s.client, searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	respSearchDomain := resp.GetSearchDomain()
+	respSavedQueries := respSearchDomain.GetSavedQueries()
+	savedQueries := make([]SavedQuery, len(respSavedQueries))
+	for idx, savedQuery := range respSavedQueries {
+		savedQueries[idx], err = mapSavedQueryDetails(savedQuery.SavedQueryDetails)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return savedQueries, nil
+}
+
+func (s *SavedQueries) Get(searchDomainName, savedQueryName string) (*SavedQuery, error) {
+	savedQueries, err := s.List(searchDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, savedQuery := range savedQueries {
+		if savedQuery.Name == savedQueryName {
+			return &savedQuery, nil
+		}
+	}
+
+	return nil, SavedQueryNotFound(savedQueryName)
+}
+
+func (s *SavedQueries) Create(searchDomainName string, newSavedQuery *SavedQuery) (*SavedQuery, error) {
+	if searchDomainName == "" {
+		return nil, fmt.Errorf("searchDomainName must not be empty")
+	}
+
+	if newSavedQuery == nil {
+		return nil, fmt.Errorf("newSavedQuery must not be nil")
+	}
+
+	var options *string
+	if newSavedQuery.Options != nil {
+		data, err := json.Marshal(jsonCompatible(newSavedQuery.Options))
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode options: %w", err)
+		}
+		encoded := string(data)
+		options = &encoded
+	}
+
+	resp, err := humiographql.CreateSavedQuery(
+		context.Background(),
+		s.client,
+		searchDomainName,
+		newSavedQuery.Name,
+		newSavedQuery.QueryString,
+		emptyToNil(newSavedQuery.Start),
+		emptyToNil(newSavedQuery.End),
+		&newSavedQuery.IsLive,
+		emptyToNil(newSavedQuery.WidgetType),
+		options,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	respCreateSavedQuery := resp.GetCreateSavedQuery()
+	savedQuery, err := mapSavedQueryDetails(respCreateSavedQuery.GetSavedQuery().SavedQueryDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &savedQuery, nil
+}
+
+func (s *SavedQueries) Delete(searchDomainName, savedQueryID string) error {
+	if savedQueryID == "" {
+		return fmt.Errorf("savedQueryID is empty")
+	}
+
+	_, err := humiographql.DeleteSavedQuery(context.Background(), s.client, searchDomainName, savedQueryID)
+	return err
+}
+
+func mapSavedQueryDetails(savedQuery humiographql.SavedQueryDetails) (SavedQuery, error) {
+	var options map[string]interface{}
+	if len(savedQuery.GetOptions()) > 0 {
+		if err := json.Unmarshal(savedQuery.GetOptions(), &options); err != nil {
+			return SavedQuery{}, fmt.Errorf("unable to decode options of saved query %q: %w", savedQuery.GetName(), err)
+		}
+	}
+
+	query := savedQuery.GetQuery()
+	return SavedQuery{
+		ID:          savedQuery.GetId(),
+		Name:        savedQuery.GetName(),
+		Description: savedQuery.GetDescription(),
+		QueryString: query.GetQueryString(),
+		Start:       query.GetStart(),
+		End:         query.GetEnd(),
+		IsLive:      query.GetIsLive(),
+		WidgetType:  savedQuery.GetWidgetType(),
+		Options:     options,
+	}, nil
+}
+
+func emptyToNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// jsonCompatible converts the maps with interface{} keys produced when decoding YAML into maps which can be encoded as JSON.
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = jsonCompatible(value)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = jsonCompatible(v[i])
+		}
+		return list
+	default:
+		return v
+	}
+}
@@ -21,7 +21,9 @@ type ScheduledSearch struct {
 	ActionNames        []string `yaml:"actionNames"`
 	OwnershipRunAsID   string   `yaml:"ownershipRunAsID"`
 	Labels             []string
-	QueryOwnershipType string `yaml:"queryOwnershipType"`
+	QueryOwnershipType string  `yaml:"queryOwnershipType"`
+	LastError          *string `yaml:"-"`
+	LastTriggered      *int64  `yaml:"-"`
 }
 
 type ScheduledSearches struct {
@@ -63,6 +65,8 @@ func (a *ScheduledSearches) List(searchDomainName string) ([]ScheduledSearch, er
 			TimeZone:           scheduledSearch.GetTimeZone(),
 			BackfillLimit:      scheduledSearch.GetBackfillLimit(),
 			Enabled:            scheduledSearch.GetEnabled(),
+			LastError:          scheduledSearch.GetLastError(),
+			LastTriggered:      scheduledSearch.GetLastTriggered(),
 			ActionNames:        actionNames,
 			Labels:             scheduledSearch.GetLabels(),
 			OwnershipRunAsID:   runAsUserID,
@@ -72,6 +76,13 @@ func (a *ScheduledSearches) List(searchDomainName string) ([]ScheduledSearch, er
 	return scheduledSearches, nil
 }
 
+// ListAll lists the scheduled searches of several search domains concurrently.
+func (a *ScheduledSearches) ListAll(ctx context.Context, searchDomainNames []string, opts BulkOptions) []BulkResult[string, []ScheduledSearch] {
+	return Bulk(ctx, searchDomainNames, opts, func(_ context.Context, name string) ([]ScheduledSearch, error) {
+		return a.List(name)
+	})
+}
+
 func (a *ScheduledSearches) Create(searchDomainName string, newScheduledSearch *ScheduledSearch) (*ScheduledSearch, error) {
 	if searchDomainName == "" {
 		return nil, fmt.Errorf("searchDomainName must not be empty")
@@ -129,6 +140,8 @@ func (a *ScheduledSearches) Create(searchDomainName string, newScheduledSearch *
 		Schedule:           respScheduledSearch.GetSchedule(),
 		BackfillLimit:      respScheduledSearch.GetBackfillLimit(),
 		Enabled:            respScheduledSearch.GetEnabled(),
+		LastError:          respScheduledSearch.GetLastError(),
+		LastTriggered:      respScheduledSearch.GetLastTriggered(),
 		ActionNames:        actionNames,
 		OwnershipRunAsID:   runAsUserID,
 		Labels:             respScheduledSearch.GetLabels(),
@@ -24,6 +24,32 @@ type Query struct {
 	TimezoneOffset             *int              `json:"timeZoneOffsetMinutes,omitempty"`
 	Arguments                  map[string]string `json:"arguments,omitempty"`
 	ShowQueryEventDistribution bool              `json:"showQueryEventDistribution,omitempty"`
+	// StartMillis and EndMillis give an absolute time range in milliseconds since the epoch, and take precedence over Start and End.
+	StartMillis *int64 `json:"-"`
+	EndMillis   *int64 `json:"-"`
+}
+
+// MarshalJSON encodes the start and end of the query as relative times, or as numbers if given in milliseconds.
+func (q Query) MarshalJSON() ([]byte, error) {
+	type query Query
+	var start, end interface{}
+	if q.Start != "" {
+		start = q.Start
+	}
+	if q.StartMillis != nil {
+		start = *q.StartMillis
+	}
+	if q.End != "" {
+		end = q.End
+	}
+	if q.EndMillis != nil {
+		end = *q.EndMillis
+	}
+	return json.Marshal(struct {
+		query
+		Start interface{} `json:"start,omitempty"`
+		End   interface{} `json:"end,omitempty"`
+	}{query(q), start, end})
 }
 
 type QueryResultMetadata struct {
@@ -84,7 +110,7 @@ func (q QueryJobs) Create(repository string, query Query) (string, error) {
 		return "", QueryError{string(body)}
 	case http.StatusOK:
 	default:
-		return "", fmt.Errorf("could not create query job, got status code %d", resp.StatusCode)
+		return "", errorFromStatus(resp, fmt.Sprintf("could not create query job, got status code %d", resp.StatusCode))
 	}
 
 	var jsonResponse struct {
@@ -112,7 +138,7 @@ func (q *QueryJobs) PollContext(ctx context.Context, repository string, id strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return QueryResult{}, fmt.Errorf("error polling query job, got status code %d", resp.StatusCode)
+		return QueryResult{}, errorFromStatus(resp, fmt.Sprintf("error polling query job, got status code %d", resp.StatusCode))
 	}
 
 	var result QueryResult
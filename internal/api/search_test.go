@@ -0,0 +1,32 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQueryMarshalJSON(t *testing.T) {
+	startMillis, endMillis := int64(1700000000000), int64(1700003600000)
+
+	tests := []struct {
+		name     string
+		query    Query
+		expected string
+	}{
+		{"relative", Query{QueryString: "error", Start: "24h", End: "1h"}, `{"queryString":"error","start":"24h","end":"1h"}`},
+		{"millis", Query{QueryString: "error", StartMillis: &startMillis, EndMillis: &endMillis}, `{"queryString":"error","start":1700000000000,"end":1700003600000}`},
+		{"millis take precedence", Query{QueryString: "error", Start: "24h", StartMillis: &startMillis}, `{"queryString":"error","start":1700000000000}`},
+		{"no time range", Query{QueryString: "error", Live: true}, `{"queryString":"error","isLive":true}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, data)
+			}
+		})
+	}
+}
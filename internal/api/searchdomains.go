@@ -2,6 +2,10 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/humio/cli/internal/api/humiographql"
 )
@@ -11,11 +15,21 @@ type SearchDomains struct {
 }
 
 type SearchDomain struct {
+	ID              string
 	Name            string
 	Description     *string
 	AutomaticSearch bool
 }
 
+// DeletedSearchDomain is a repository or view which has been deleted but not yet permanently removed.
+type DeletedSearchDomain struct {
+	ID                   string
+	Name                 string
+	Type                 string
+	DeletedAt            time.Time
+	PermanentlyDeletedAt *time.Time
+}
+
 func (s *Client) SearchDomains() *SearchDomains { return &SearchDomains{client: s} }
 
 func (s *SearchDomains) Get(name string) (*SearchDomain, error) {
@@ -26,8 +40,118 @@ func (s *SearchDomains) Get(name string) (*SearchDomain, error) {
 
 	searchDomain := resp.GetSearchDomain()
 	return &SearchDomain{
+		ID:              searchDomain.GetId(),
 		Name:            searchDomain.GetName(),
 		Description:     searchDomain.GetDescription(),
 		AutomaticSearch: searchDomain.GetAutomaticSearch(),
 	}, nil
 }
+
+// List returns all repositories and views.
+func (s *SearchDomains) List() ([]SearchDomain, error) {
+	resp, err := humiographql.ListSearchDomains(context.Background(), s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	respSearchDomains := resp.GetSearchDomains()
+	searchDomains := make([]SearchDomain, len(respSearchDomains))
+	for idx, searchDomain := range respSearchDomains {
+		searchDomains[idx] = SearchDomain{
+			ID:              searchDomain.GetId(),
+			Name:            searchDomain.GetName(),
+			AutomaticSearch: searchDomain.GetAutomaticSearch(),
+		}
+	}
+
+	sort.Slice(searchDomains, func(i, j int) bool {
+		return strings.ToLower(searchDomains[i].Name) < strings.ToLower(searchDomains[j].Name)
+	})
+	return searchDomains, nil
+}
+
+// Rename changes the name of a repository or view.
+func (s *SearchDomains) Rename(name string, newName string) error {
+	_, err := humiographql.RenameSearchDomain(context.Background(), s.client, name, newName)
+	return err
+}
+
+// ListDeleted returns the repositories and views which have been deleted and can still be restored.
+func (s *SearchDomains) ListDeleted() ([]DeletedSearchDomain, error) {
+	resp, err := humiographql.ListDeletedSearchDomains(context.Background(), s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	var searchDomains []DeletedSearchDomain
+	for _, searchDomain := range resp.GetSearchDomains() {
+		if searchDomain.GetDeletedDate() == nil {
+			continue
+		}
+		deleted := DeletedSearchDomain{
+			ID:        searchDomain.GetId(),
+			Name:      searchDomain.GetName(),
+			DeletedAt: time.UnixMilli(*searchDomain.GetDeletedDate()),
+		}
+		if typename := searchDomain.GetTypename(); typename != nil {
+			deleted.Type = *typename
+		}
+		if permanentlyDeletedAt := searchDomain.GetPermanentlyDeletedAt(); permanentlyDeletedAt != nil {
+			t := time.UnixMilli(*permanentlyDeletedAt)
+			deleted.PermanentlyDeletedAt = &t
+		}
+		searchDomains = append(searchDomains, deleted)
+	}
+
+	sort.Slice(searchDomains, func(i, j int) bool {
+		return searchDomains[i].DeletedAt.After(searchDomains[j].DeletedAt)
+	})
+	return searchDomains, nil
+}
+
+// Restore undeletes a repository or view. It accepts either the ID or the name of the deleted search domain,
+// and requires the ID if several deleted search domains share the name.
+func (s *SearchDomains) Restore(nameOrID string) (*SearchDomain, error) {
+	deleted, err := s.ListDeleted()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := deletedSearchDomainID(deleted, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := humiographql.RestoreDeletedSearchDomain(context.Background(), s.client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	respSearchDomain := resp.GetRestoreDeletedSearchDomain()
+	return &SearchDomain{
+		ID:   respSearchDomain.GetId(),
+		Name: respSearchDomain.GetName(),
+	}, nil
+}
+
+// deletedSearchDomainID returns the ID of the deleted search domain with the ID nameOrID, or else of the single one named nameOrID.
+func deletedSearchDomainID(deleted []DeletedSearchDomain, nameOrID string) (string, error) {
+	var ids []string
+	for _, searchDomain := range deleted {
+		if searchDomain.ID == nameOrID {
+			return searchDomain.ID, nil
+		}
+		if searchDomain.Name == nameOrID {
+			ids = append(ids, searchDomain.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", SearchDomainNotFound(nameOrID)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", ValidationError{Message: fmt.Sprintf("several deleted repositories or views are named %q, use the ID instead", nameOrID)}
+	}
+}
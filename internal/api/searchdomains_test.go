@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeletedSearchDomainID(t *testing.T) {
+	deleted := []DeletedSearchDomain{
+		{ID: "id-1", Name: "logs", Type: "Repository"},
+		{ID: "id-2", Name: "logs", Type: "Repository"},
+		{ID: "id-3", Name: "metrics", Type: "View"},
+		{ID: "id-4", Name: "id-3", Type: "View"},
+	}
+
+	tests := []struct {
+		nameOrID string
+		expected string
+		err      error
+	}{
+		{"metrics", "id-3", nil},
+		{"id-2", "id-2", nil},
+		{"id-3", "id-3", nil},
+		{"logs", "", ValidationError{}},
+		{"traces", "", SearchDomainNotFound("traces")},
+	}
+	for _, test := range tests {
+		id, err := deletedSearchDomainID(deleted, test.nameOrID)
+		if id != test.expected {
+			t.Errorf("%s: expected ID %q, got %q", test.nameOrID, test.expected, id)
+		}
+		switch test.err.(type) {
+		case nil:
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", test.nameOrID, err)
+			}
+		case ValidationError:
+			if !errors.As(err, &ValidationError{}) {
+				t.Errorf("%s: expected a validation error, got %v", test.nameOrID, err)
+			}
+		default:
+			if err == nil || err.Error() != test.err.Error() {
+				t.Errorf("%s: expected error %v, got %v", test.nameOrID, test.err, err)
+			}
+		}
+	}
+}
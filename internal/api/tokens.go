@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/humio/cli/internal/api/humiographql"
 )
@@ -10,8 +12,164 @@ type Tokens struct {
 	client *Client
 }
 
+// TokenType is the scope of the permissions of an API token.
+type TokenType string
+
+const (
+	TokenTypeView         TokenType = "view"
+	TokenTypeOrganization TokenType = "organization"
+	TokenTypeSystem       TokenType = "system"
+)
+
+type Token struct {
+	ID          string
+	Name        string
+	Type        TokenType
+	Permissions []string
+	Views       []string
+	ExpireAt    *time.Time
+	CreatedAt   time.Time
+}
+
+// TokenInput describes an API token to create. Views is only used for view tokens.
+type TokenInput struct {
+	Name        string
+	Type        TokenType
+	Permissions []string
+	Views       []string
+	ExpireAt    *time.Time
+}
+
+const tokensPageSize = 100
+
 func (c *Client) Tokens() *Tokens { return &Tokens{client: c} }
 
+// List returns the view, organization and system API tokens. If tokenType is empty, tokens of all types are returned.
+func (t *Tokens) List(tokenType TokenType) ([]Token, error) {
+	var typeFilter []humiographql.Tokens__Type
+	switch tokenType {
+	case "":
+		typeFilter = []humiographql.Tokens__Type{
+			humiographql.Tokens__TypeViewpermissiontoken,
+			humiographql.Tokens__TypeOrganizationpermissiontoken,
+			humiographql.Tokens__TypeSystempermissiontoken,
+		}
+	case TokenTypeView:
+		typeFilter = []humiographql.Tokens__Type{humiographql.Tokens__TypeViewpermissiontoken}
+	case TokenTypeOrganization:
+		typeFilter = []humiographql.Tokens__Type{humiographql.Tokens__TypeOrganizationpermissiontoken}
+	case TokenTypeSystem:
+		typeFilter = []humiographql.Tokens__Type{humiographql.Tokens__TypeSystempermissiontoken}
+	default:
+		return nil, fmt.Errorf("unsupported token type %q", tokenType)
+	}
+
+	var tokens []Token
+	for skip := 0; ; skip += tokensPageSize {
+		limit := tokensPageSize
+		resp, err := humiographql.ListTokens(context.Background(), t.client, typeFilter, &skip, &limit)
+		if err != nil {
+			return nil, err
+		}
+
+		respTokens := resp.GetTokens()
+		for _, token := range respTokens.GetResults() {
+			if mapped, ok := mapTokenDetails(token); ok {
+				tokens = append(tokens, mapped)
+			}
+		}
+
+		if len(respTokens.GetResults()) < limit || skip+limit >= respTokens.GetTotalResults() {
+			break
+		}
+	}
+
+	return tokens, nil
+}
+
+// Get returns the token with the given ID, or the token with the given name.
+func (t *Tokens) Get(token string) (*Token, error) {
+	tokens, err := t.List("")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range tokens {
+		if candidate.ID == token {
+			return &candidate, nil
+		}
+	}
+
+	var match *Token
+	for i, candidate := range tokens {
+		if candidate.Name != token {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("multiple tokens are named %q, use the token ID instead", token)
+		}
+		match = &tokens[i]
+	}
+	if match == nil {
+		return nil, TokenNotFound(token)
+	}
+
+	return match, nil
+}
+
+// Create creates an API token and returns its secret, which cannot be retrieved later.
+func (t *Tokens) Create(token TokenInput) (string, error) {
+	var expireAt *int64
+	if token.ExpireAt != nil {
+		millis := token.ExpireAt.UnixMilli()
+		expireAt = &millis
+	}
+
+	switch token.Type {
+	case TokenTypeView:
+		viewIDs, err := t.viewIDs(token.Views)
+		if err != nil {
+			return "", err
+		}
+		permissions := make([]humiographql.Permission, len(token.Permissions))
+		for i, permission := range token.Permissions {
+			permissions[i] = humiographql.Permission(permission)
+		}
+		resp, err := humiographql.CreateViewPermissionsToken(context.Background(), t.client, token.Name, expireAt, viewIDs, permissions)
+		if err != nil {
+			return "", err
+		}
+		return resp.GetCreateViewPermissionsToken(), nil
+	case TokenTypeOrganization:
+		permissions := make([]humiographql.OrganizationPermission, len(token.Permissions))
+		for i, permission := range token.Permissions {
+			permissions[i] = humiographql.OrganizationPermission(permission)
+		}
+		resp, err := humiographql.CreateOrganizationPermissionsToken(context.Background(), t.client, token.Name, expireAt, permissions)
+		if err != nil {
+			return "", err
+		}
+		return resp.GetCreateOrganizationPermissionsToken(), nil
+	case TokenTypeSystem:
+		permissions := make([]humiographql.SystemPermission, len(token.Permissions))
+		for i, permission := range token.Permissions {
+			permissions[i] = humiographql.SystemPermission(permission)
+		}
+		resp, err := humiographql.CreateSystemPermissionsToken(context.Background(), t.client, token.Name, expireAt, permissions)
+		if err != nil {
+			return "", err
+		}
+		return resp.GetCreateSystemPermissionsToken(), nil
+	default:
+		return "", fmt.Errorf("unsupported token type %q", token.Type)
+	}
+}
+
+func (t *Tokens) Delete(tokenID string) error {
+	_, err := humiographql.DeleteToken(context.Background(), t.client, tokenID)
+	return err
+}
+
 func (t *Tokens) Rotate(tokenID string) (string, error) {
 	resp, err := humiographql.RotateTokenByID(context.Background(), t.client, tokenID)
 	if err != nil {
@@ -20,3 +178,59 @@ func (t *Tokens) Rotate(tokenID string) (string, error) {
 
 	return resp.GetRotateToken(), nil
 }
+
+func (t *Tokens) viewIDs(viewNames []string) ([]string, error) {
+	if len(viewNames) == 0 {
+		return nil, fmt.Errorf("view tokens must be given at least one view")
+	}
+
+	searchDomains, err := t.client.SearchDomains().List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list search domains: %w", err)
+	}
+	ids := make(map[string]string, len(searchDomains))
+	for _, searchDomain := range searchDomains {
+		ids[searchDomain.Name] = searchDomain.ID
+	}
+
+	viewIDs := make([]string, len(viewNames))
+	for i, name := range viewNames {
+		id, ok := ids[name]
+		if !ok {
+			return nil, SearchDomainNotFound(name)
+		}
+		viewIDs[i] = id
+	}
+	return viewIDs, nil
+}
+
+func mapTokenDetails(token humiographql.ListTokensTokensTokenQueryResultSetResultsToken) (Token, bool) {
+	mapped := Token{
+		ID:        token.GetId(),
+		Name:      token.GetName(),
+		CreatedAt: time.UnixMilli(token.GetCreatedAt()),
+	}
+	if token.GetExpireAt() != nil {
+		expireAt := time.UnixMilli(*token.GetExpireAt())
+		mapped.ExpireAt = &expireAt
+	}
+
+	switch v := token.(type) {
+	case *humiographql.ListTokensTokensTokenQueryResultSetResultsViewPermissionsToken:
+		mapped.Type = TokenTypeView
+		mapped.Permissions = v.GetPermissions()
+		for _, view := range v.GetViews() {
+			mapped.Views = append(mapped.Views, view.GetName())
+		}
+	case *humiographql.ListTokensTokensTokenQueryResultSetResultsOrganizationPermissionsToken:
+		mapped.Type = TokenTypeOrganization
+		mapped.Permissions = v.GetPermissions()
+	case *humiographql.ListTokensTokensTokenQueryResultSetResultsSystemPermissionsToken:
+		mapped.Type = TokenTypeSystem
+		mapped.Permissions = v.GetPermissions()
+	default:
+		return Token{}, false
+	}
+
+	return mapped, true
+}
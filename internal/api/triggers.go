@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/humio/cli/internal/api/humiographql"
+)
+
+// TriggerKind is the kind of a trigger, i.e. something which runs a query and fires actions based on its result.
+type TriggerKind string
+
+const (
+	TriggerKindAlert           TriggerKind = "alert"
+	TriggerKindFilterAlert     TriggerKind = "filter-alert"
+	TriggerKindAggregateAlert  TriggerKind = "aggregate-alert"
+	TriggerKindScheduledSearch TriggerKind = "scheduled-search"
+)
+
+// TriggerKinds lists all kinds of triggers.
+var TriggerKinds = []TriggerKind{
+	TriggerKindAlert,
+	TriggerKindFilterAlert,
+	TriggerKindAggregateAlert,
+	TriggerKindScheduledSearch,
+}
+
+// Trigger is the common part of alerts, filter alerts, aggregate alerts and scheduled searches.
+type Trigger struct {
+	Kind         TriggerKind
+	ID           string
+	Name         string
+	SearchDomain string
+	Enabled      bool
+	Labels       []string
+	// LastError is set while the trigger is in an error state, until the error is cleared or the trigger runs successfully.
+	LastError *string
+	// LastTriggered is the time in milliseconds since the epoch when the trigger last fired its actions.
+	LastTriggered *int64
+}
+
+type Triggers struct {
+	client *Client
+}
+
+func (c *Client) Triggers() *Triggers { return &Triggers{client: c} }
+
+// HasLabel reports whether the trigger has the given label.
+func (t Trigger) HasLabel(label string) bool {
+	return containsString(t.Labels, label)
+}
+
+// LabelsChangeable reports whether labels of the trigger can be changed individually with AddLabel and RemoveLabel.
+func (t Trigger) LabelsChangeable() bool {
+	return t.Kind == TriggerKindAlert || t.Kind == TriggerKindScheduledSearch
+}
+
+// List returns the triggers of the given kinds in a search domain.
+func (t *Triggers) List(searchDomainName string, kinds []TriggerKind) ([]Trigger, error) {
+	var triggers []Trigger
+	for _, kind := range kinds {
+		switch kind {
+		case TriggerKindAlert:
+			alerts, err := t.client.Alerts().List(searchDomainName)
+			if err != nil {
+				return nil, err
+			}
+			for _, alert := range alerts {
+				triggers = append(triggers, Trigger{Kind: kind, ID: alert.ID, Name: alert.Name, SearchDomain: searchDomainName, Enabled: alert.Enabled, Labels: alert.Labels, LastError: alert.LastError, LastTriggered: alert.TimeOfLastTrigger})
+			}
+		case TriggerKindFilterAlert:
+			filterAlerts, err := t.client.FilterAlerts().List(searchDomainName)
+			if err != nil {
+				return nil, err
+			}
+			for _, filterAlert := range filterAlerts {
+				triggers = append(triggers, Trigger{Kind: kind, ID: filterAlert.ID, Name: filterAlert.Name, SearchDomain: searchDomainName, Enabled: filterAlert.Enabled, Labels: filterAlert.Labels, LastError: filterAlert.LastError, LastTriggered: filterAlert.LastTriggered})
+			}
+		case TriggerKindAggregateAlert:
+			aggregateAlerts, err := t.client.AggregateAlerts().List(searchDomainName)
+			if err != nil {
+				return nil, err
+			}
+			for _, aggregateAlert := range aggregateAlerts {
+				triggers = append(triggers, Trigger{Kind: kind, ID: aggregateAlert.ID, Name: aggregateAlert.Name, SearchDomain: searchDomainName, Enabled: aggregateAlert.Enabled, Labels: aggregateAlert.Labels, LastError: aggregateAlert.LastError, LastTriggered: aggregateAlert.LastTriggered})
+			}
+		case TriggerKindScheduledSearch:
+			scheduledSearches, err := t.client.ScheduledSearches().List(searchDomainName)
+			if err != nil {
+				return nil, err
+			}
+			for _, scheduledSearch := range scheduledSearches {
+				triggers = append(triggers, Trigger{Kind: kind, ID: scheduledSearch.ID, Name: scheduledSearch.Name, SearchDomain: searchDomainName, Enabled: scheduledSearch.Enabled, Labels: scheduledSearch.Labels, LastError: scheduledSearch.LastError, LastTriggered: scheduledSearch.LastTriggered})
+			}
+		default:
+			return nil, fmt.Errorf("unknown trigger kind %q", kind)
+		}
+	}
+	return triggers, nil
+}
+
+// SetEnabled enables or disables a trigger without otherwise changing it.
+func (t *Triggers) SetEnabled(trigger Trigger, enabled bool) error {
+	ctx := context.Background()
+	var err error
+	switch trigger.Kind {
+	case TriggerKindAlert:
+		if enabled {
+			_, err = humiographql.EnableAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		} else {
+			_, err = humiographql.DisableAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		}
+	case TriggerKindFilterAlert:
+		if enabled {
+			_, err = humiographql.EnableFilterAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		} else {
+			_, err = humiographql.DisableFilterAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		}
+	case TriggerKindAggregateAlert:
+		if enabled {
+			_, err = humiographql.EnableAggregateAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		} else {
+			_, err = humiographql.DisableAggregateAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		}
+	case TriggerKindScheduledSearch:
+		if enabled {
+			_, err = humiographql.EnableScheduledSearch(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		} else {
+			_, err = humiographql.DisableScheduledSearch(ctx, t.client, trigger.SearchDomain, trigger.ID)
+		}
+	default:
+		err = fmt.Errorf("unknown trigger kind %q", trigger.Kind)
+	}
+	return err
+}
+
+// ClearError clears the error state of a trigger, e.g. once the cause of the error has been fixed.
+func (t *Triggers) ClearError(trigger Trigger) error {
+	ctx := context.Background()
+	var err error
+	switch trigger.Kind {
+	case TriggerKindAlert:
+		_, err = humiographql.ClearErrorOnAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+	case TriggerKindFilterAlert:
+		_, err = humiographql.ClearErrorOnFilterAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+	case TriggerKindAggregateAlert:
+		_, err = humiographql.ClearErrorOnAggregateAlert(ctx, t.client, trigger.SearchDomain, trigger.ID)
+	case TriggerKindScheduledSearch:
+		_, err = humiographql.ClearErrorOnScheduledSearch(ctx, t.client, trigger.SearchDomain, trigger.ID)
+	default:
+		err = fmt.Errorf("unknown trigger kind %q", trigger.Kind)
+	}
+	return err
+}
+
+// AddLabel adds a label to a trigger. Only alerts and scheduled searches support changing labels individually.
+func (t *Triggers) AddLabel(trigger Trigger, label string) error {
+	ctx := context.Background()
+	switch trigger.Kind {
+	case TriggerKindAlert:
+		_, err := humiographql.AddAlertLabel(ctx, t.client, trigger.SearchDomain, trigger.ID, label)
+		return err
+	case TriggerKindScheduledSearch:
+		_, err := humiographql.AddScheduledSearchLabel(ctx, t.client, trigger.SearchDomain, trigger.ID, label)
+		return err
+	default:
+		return ValidationError{Message: fmt.Sprintf("labels of a %s cannot be changed individually, update it with install instead", trigger.Kind)}
+	}
+}
+
+// RemoveLabel removes a label from a trigger. Only alerts and scheduled searches support changing labels individually.
+func (t *Triggers) RemoveLabel(trigger Trigger, label string) error {
+	ctx := context.Background()
+	switch trigger.Kind {
+	case TriggerKindAlert:
+		_, err := humiographql.RemoveAlertLabel(ctx, t.client, trigger.SearchDomain, trigger.ID, label)
+		return err
+	case TriggerKindScheduledSearch:
+		_, err := humiographql.RemoveScheduledSearchLabel(ctx, t.client, trigger.SearchDomain, trigger.ID, label)
+		return err
+	default:
+		return ValidationError{Message: fmt.Sprintf("labels of a %s cannot be changed individually, update it with install instead", trigger.Kind)}
+	}
+}
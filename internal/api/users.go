@@ -49,6 +49,27 @@ func (u *Users) List() ([]User, error) {
 	return users, nil
 }
 
+// SearchDomainRoles returns the roles assigned directly to each user, rather than through a group, keyed by username.
+func (u *Users) SearchDomainRoles() (map[string][]GroupViewRole, error) {
+	resp, err := humiographql.ListUserSearchDomainRoles(context.Background(), u.client)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := map[string][]GroupViewRole{}
+	for _, user := range resp.GetUsers() {
+		for _, searchDomainRole := range user.GetSearchDomainRoles() {
+			searchDomain := searchDomainRole.GetSearchDomain()
+			role := searchDomainRole.GetRole()
+			roles[user.GetUsername()] = append(roles[user.GetUsername()], GroupViewRole{
+				View: searchDomain.GetName(),
+				Role: role.GetDisplayName(),
+			})
+		}
+	}
+	return roles, nil
+}
+
 func (u *Users) Get(username string) (User, error) {
 	resp, err := humiographql.GetUsersByUsername(context.Background(), u.client, username)
 	if err != nil {
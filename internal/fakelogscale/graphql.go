@@ -0,0 +1,252 @@
+package fakelogscale
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// object is a GraphQL object in the shape returned by the server, keyed by field name.
+// Values are either plain values, other objects, lists or a resolver for fields taking arguments.
+type object map[string]interface{}
+
+// resolver computes the value of a field from its arguments.
+type resolver func(args map[string]interface{}) (interface{}, error)
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type graphqlResponse struct {
+	Data   interface{}   `json:"data"`
+	Errors gqlerror.List `json:"errors,omitempty"`
+}
+
+var (
+	loadSchemaOnce sync.Once
+	schema         *ast.Schema
+	schemaErr      error
+)
+
+func loadSchema() (*ast.Schema, error) {
+	loadSchemaOnce.Do(func() {
+		// The schema the client is generated from is read from the source tree rather than embedded in
+		// internal/api/humiographql, which would add it to the humioctl binary.
+		_, file, _, _ := runtime.Caller(0)
+		path := filepath.Join(filepath.Dir(file), "..", "api", "humiographql", "schema", "_schema.graphql")
+		input, err := os.ReadFile(path)
+		if err != nil {
+			schemaErr = fmt.Errorf("could not read the GraphQL schema: %w", err)
+			return
+		}
+		// The bundled schema declares the built-in scalars itself, so it is loaded without the prelude.
+		schema, schemaErr = validator.LoadSchema(&ast.Source{Name: "_schema.graphql", Input: string(input)})
+	})
+	return schema, schemaErr
+}
+
+type executor struct {
+	schema    *ast.Schema
+	doc       *ast.QueryDocument
+	variables map[string]interface{}
+	errors    gqlerror.List
+}
+
+func execute(s *ast.Schema, req graphqlRequest, query, mutation object) graphqlResponse {
+	doc, errs := gqlparser.LoadQuery(s, req.Query)
+	if len(errs) > 0 {
+		return graphqlResponse{Errors: errs}
+	}
+
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		return graphqlResponse{Errors: gqlerror.List{gqlerror.Errorf("operation %q not found", req.OperationName)}}
+	}
+
+	variables, err := validator.VariableValues(s, op, req.Variables)
+	if err != nil {
+		return graphqlResponse{Errors: gqlerror.List{&gqlerror.Error{Message: err.Error()}}}
+	}
+
+	e := &executor{schema: s, doc: doc, variables: variables}
+
+	var root object
+	var typeName string
+	switch op.Operation {
+	case ast.Query:
+		root, typeName = query, s.Query.Name
+	case ast.Mutation:
+		root, typeName = mutation, s.Mutation.Name
+	default:
+		return graphqlResponse{Errors: gqlerror.List{gqlerror.Errorf("unsupported operation type %q", op.Operation)}}
+	}
+
+	data := e.selectObject(root, typeName, op.SelectionSet, ast.Path{})
+	return graphqlResponse{Data: data, Errors: e.errors}
+}
+
+func (e *executor) selectObject(obj object, typeName string, set ast.SelectionSet, path ast.Path) map[string]interface{} {
+	if t, ok := obj["__typename"].(string); ok {
+		typeName = t
+	}
+
+	result := map[string]interface{}{}
+	for _, field := range e.collectFields(typeName, set) {
+		key := field.Alias
+		if key == "" {
+			key = field.Name
+		}
+		fieldPath := append(append(ast.Path{}, path...), ast.PathName(key))
+
+		if field.Name == "__typename" {
+			result[key] = typeName
+			continue
+		}
+
+		value := obj[field.Name]
+		if r, ok := value.(resolver); ok {
+			var err error
+			value, err = r(field.ArgumentMap(e.variables))
+			if err != nil {
+				e.errors = append(e.errors, &gqlerror.Error{Message: err.Error(), Path: fieldPath})
+				result[key] = nil
+				continue
+			}
+		}
+
+		result[key] = e.complete(value, field, fieldPath)
+	}
+
+	return result
+}
+
+func (e *executor) complete(value interface{}, field *ast.Field, path ast.Path) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case object:
+		if v == nil {
+			return nil
+		}
+		return e.selectObject(v, field.Definition.Type.Name(), field.SelectionSet, path)
+	case []object:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = e.complete(v[i], field, append(append(ast.Path{}, path...), ast.PathIndex(i)))
+		}
+		return list
+	default:
+		return v
+	}
+}
+
+// collectFields flattens the selection set for an object of the given type, expanding fragments which apply to it.
+func (e *executor) collectFields(typeName string, set ast.SelectionSet) []*ast.Field {
+	var fields []*ast.Field
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *ast.Field:
+			fields = append(fields, s)
+		case *ast.InlineFragment:
+			if e.typeMatches(typeName, s.TypeCondition) {
+				fields = append(fields, e.collectFields(typeName, s.SelectionSet)...)
+			}
+		case *ast.FragmentSpread:
+			fragment := e.doc.Fragments.ForName(s.Name)
+			if fragment != nil && e.typeMatches(typeName, fragment.TypeCondition) {
+				fields = append(fields, e.collectFields(typeName, fragment.SelectionSet)...)
+			}
+		}
+	}
+	return fields
+}
+
+func (e *executor) typeMatches(typeName, condition string) bool {
+	if condition == "" || condition == typeName {
+		return true
+	}
+
+	def := e.schema.Types[typeName]
+	if def == nil {
+		return false
+	}
+	for _, iface := range def.Interfaces {
+		if iface == condition {
+			return true
+		}
+	}
+
+	if union := e.schema.Types[condition]; union != nil && union.Kind == ast.Union {
+		for _, t := range union.Types {
+			if t == typeName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func argString(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func argStringPtr(args map[string]interface{}, name string) *string {
+	s, ok := args[name].(string)
+	if !ok {
+		return nil
+	}
+	return &s
+}
+
+func argBool(args map[string]interface{}, name string) bool {
+	b, _ := args[name].(bool)
+	return b
+}
+
+func argInt(args map[string]interface{}, name string) int {
+	switch v := args[name].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+func argObject(args map[string]interface{}, name string) map[string]interface{} {
+	m, _ := args[name].(map[string]interface{})
+	return m
+}
+
+func argList(args map[string]interface{}, name string) []interface{} {
+	l, _ := args[name].([]interface{})
+	return l
+}
+
+func argStrings(args map[string]interface{}, name string) []string {
+	list := argList(args, name)
+	strings := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			strings = append(strings, s)
+		}
+	}
+	return strings
+}
+
+func errNotFound(entity, name string) error {
+	return fmt.Errorf("%s %q not found", entity, name)
+}
@@ -0,0 +1,1252 @@
+package fakelogscale
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// tokenTypes maps the types of API tokens to the values of the type filter of the tokens query.
+var tokenTypes = map[string]string{
+	"ViewPermissionsToken":         "ViewPermissionToken",
+	"OrganizationPermissionsToken": "OrganizationPermissionToken",
+	"SystemPermissionsToken":       "SystemPermissionToken",
+}
+
+// actionTypes maps the action creation mutations to the type of action they create.
+var actionTypes = map[string]string{
+	"createEmailAction":            "EmailAction",
+	"createHumioRepoAction":        "HumioRepoAction",
+	"createOpsGenieAction":         "OpsGenieAction",
+	"createPagerDutyAction":        "PagerDutyAction",
+	"createSlackAction":            "SlackAction",
+	"createSlackPostMessageAction": "SlackPostMessageAction",
+	"createVictorOpsAction":        "VictorOpsAction",
+	"createUploadFileAction":       "UploadFileAction",
+	"createWebhookAction":          "WebhookAction",
+}
+
+func (s *Server) queryRoot() object {
+	return object{
+		"viewer": object{"username": s.Viewer},
+		"featureFlags": []object{
+			{"flag": "ExportToBucket", "description": "Export data to bucket storage.", "experimental": false},
+			{"flag": "RepeatingQueries", "description": "Enable repeating queries.", "experimental": false},
+		},
+		"searchDomain": resolver(func(args map[string]interface{}) (interface{}, error) {
+			name := argString(args, "name")
+			d, ok := s.searchDomains[name]
+			if !ok {
+				return nil, errNotFound("search domain", name)
+			}
+			return s.searchDomainObject(d), nil
+		}),
+		"searchDomains": resolver(func(args map[string]interface{}) (interface{}, error) {
+			var list []object
+			for _, name := range s.searchDomainNames() {
+				list = append(list, s.searchDomainObject(s.searchDomains[name]))
+			}
+			return list, nil
+		}),
+		"analyzeQuery": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			if viewName := argStringPtr(input, "viewName"); viewName != nil {
+				if _, err := s.searchDomain(*viewName); err != nil {
+					return nil, err
+				}
+			}
+			return analyzeQuery(argString(input, "queryString")), nil
+		}),
+		"repository": resolver(func(args map[string]interface{}) (interface{}, error) {
+			name := argString(args, "name")
+			d, ok := s.searchDomains[name]
+			if !ok || !d.isRepository {
+				return nil, errNotFound("repository", name)
+			}
+			return s.searchDomainObject(d), nil
+		}),
+		"repositories": resolver(func(args map[string]interface{}) (interface{}, error) {
+			var list []object
+			for _, name := range s.searchDomainNames() {
+				if d := s.searchDomains[name]; d.isRepository {
+					list = append(list, s.searchDomainObject(d))
+				}
+			}
+			return list, nil
+		}),
+		"roles": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.roles, nil
+		}),
+		"role": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.role(argString(args, "roleId"))
+		}),
+		"blockedQueries": resolver(func(args map[string]interface{}) (interface{}, error) {
+			list := []object{}
+			for _, blockedQuery := range s.blockedQueries {
+				if blockedQuery["limitedToOrganization"] == true || argBool(args, "clusterWide") {
+					list = append(list, blockedQuery)
+				}
+			}
+			return list, nil
+		}),
+		"queryQuotaDefaultSettings": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return queryQuotaSettings(s.queryQuotaDefaults, "Default"), nil
+		}),
+		"queryQuotaUserSettings": resolver(func(args map[string]interface{}) (interface{}, error) {
+			usernames := make([]string, 0, len(s.queryQuotaUsers))
+			for username := range s.queryQuotaUsers {
+				if search := argString(args, "username"); search == "" || username == search {
+					usernames = append(usernames, username)
+				}
+			}
+			sort.Strings(usernames)
+
+			list := make([]object, len(usernames))
+			for i, username := range usernames {
+				list[i] = object{"username": username, "settings": queryQuotaSettings(s.queryQuotaUsers[username], "UserSpecified")}
+			}
+			return list, nil
+		}),
+		"groupsPage": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return object{"page": s.groups}, nil
+		}),
+		"group": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.group(argString(args, "groupId"))
+		}),
+		"tokens": resolver(func(args map[string]interface{}) (interface{}, error) {
+			types := map[string]bool{}
+			for _, tokenType := range argStrings(args, "typeFilter") {
+				types[tokenType] = true
+			}
+
+			var list []object
+			for _, token := range s.tokens {
+				if len(types) == 0 || types[tokenTypes[token["__typename"].(string)]] {
+					list = append(list, token)
+				}
+			}
+			sort.SliceStable(list, func(i, j int) bool { return list[i]["name"].(string) < list[j]["name"].(string) })
+
+			total := len(list)
+			if skip := argInt(args, "skip"); skip < len(list) {
+				list = list[skip:]
+			} else {
+				list = nil
+			}
+			if args["limit"] != nil && argInt(args, "limit") < len(list) {
+				list = list[:argInt(args, "limit")]
+			}
+			return object{"totalResults": total, "results": append([]object{}, list...)}, nil
+		}),
+		"users": resolver(func(args map[string]interface{}) (interface{}, error) {
+			search := argString(args, "search")
+			usernames := make([]string, 0, len(s.users))
+			for username := range s.users {
+				if search == "" || username == search {
+					usernames = append(usernames, username)
+				}
+			}
+			sort.Strings(usernames)
+
+			list := make([]object, len(usernames))
+			for i, username := range usernames {
+				list[i] = s.users[username]
+			}
+			return list, nil
+		}),
+	}
+}
+
+func (s *Server) mutationRoot() object {
+	root := object{
+		"createRepository": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.addSearchDomain(argString(args, "name"), argStringPtr(args, "description"), true, nil)
+			if err != nil {
+				return nil, err
+			}
+			return object{"repository": s.searchDomainObject(d)}, nil
+		}),
+		"createView": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.addSearchDomain(argString(args, "name"), argStringPtr(args, "description"), false, viewConnections(argList(args, "connections")))
+			if err != nil {
+				return nil, err
+			}
+			return s.searchDomainObject(d), nil
+		}),
+		"updateView": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.searchDomain(argString(args, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+			d.connections = viewConnections(argList(args, "connections"))
+			return s.searchDomainObject(d), nil
+		}),
+		"deleteSearchDomain": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.searchDomain(argString(args, "name"))
+			if err != nil {
+				return nil, err
+			}
+			delete(s.searchDomains, d.name)
+			return object{"result": true}, nil
+		}),
+		"renameSearchDomain": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.searchDomain(argString(args, "name"))
+			if err != nil {
+				return nil, err
+			}
+			newName := argString(args, "renameTo")
+			if _, exists := s.searchDomains[newName]; exists {
+				return nil, fmt.Errorf("a search domain with the name %q already exists", newName)
+			}
+
+			delete(s.searchDomains, d.name)
+			for _, view := range s.searchDomains {
+				for i := range view.connections {
+					if view.connections[i].repositoryName == d.name {
+						view.connections[i].repositoryName = newName
+					}
+				}
+			}
+			d.name = newName
+			s.searchDomains[newName] = d
+			return s.searchDomainObject(d), nil
+		}),
+		"updateDescriptionForSearchDomain": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.searchDomain(argString(args, "name"))
+			if err != nil {
+				return nil, err
+			}
+			d.description = argStringPtr(args, "newDescription")
+			return object{}, nil
+		}),
+		"setAutomaticSearching": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.searchDomain(argString(args, "name"))
+			if err != nil {
+				return nil, err
+			}
+			d.automaticSearch = argBool(args, "automaticSearch")
+			return object{}, nil
+		}),
+		"updateRetention": resolver(func(args map[string]interface{}) (interface{}, error) {
+			d, err := s.searchDomain(argString(args, "repositoryName"))
+			if err != nil {
+				return nil, err
+			}
+			for _, field := range []string{"timeBasedRetention", "ingestSizeBasedRetention", "storageSizeBasedRetention"} {
+				if _, ok := args[field]; ok {
+					d.retention[field] = args[field]
+				}
+			}
+			return object{}, nil
+		}),
+		"addUserV2": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			if _, exists := s.users[argString(input, "username")]; exists {
+				return nil, fmt.Errorf("user %q already exists", argString(input, "username"))
+			}
+			return s.addUser(input), nil
+		}),
+		"updateUser": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			user, ok := s.users[argString(input, "username")]
+			if !ok {
+				return nil, errNotFound("user", argString(input, "username"))
+			}
+			for _, field := range []string{"company", "isRoot", "fullName", "picture", "email", "countryCode"} {
+				if v, ok := input[field]; ok && v != nil {
+					user[field] = v
+				}
+			}
+			return object{}, nil
+		}),
+		"removeUser": resolver(func(args map[string]interface{}) (interface{}, error) {
+			username := argString(argObject(args, "input"), "username")
+			user, ok := s.users[username]
+			if !ok {
+				return nil, errNotFound("user", username)
+			}
+			delete(s.users, username)
+			return object{"user": user}, nil
+		}),
+		"createRole": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			for _, role := range s.roles {
+				if role["displayName"] == argString(input, "displayName") {
+					return nil, fmt.Errorf("role %q already exists", role["displayName"])
+				}
+			}
+
+			role := object{"id": s.newID(), "description": nil}
+			setRoleFields(role, input)
+			s.roles = append(s.roles, role)
+			return object{"role": role}, nil
+		}),
+		"updateRole": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			for _, role := range s.roles {
+				if role["id"] == argString(input, "roleId") {
+					setRoleFields(role, input)
+					role["description"] = argStringPtr(input, "description")
+					return object{"role": role}, nil
+				}
+			}
+			return nil, errNotFound("role", argString(input, "roleId"))
+		}),
+		"addToBlocklist": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			var view interface{}
+			if viewName := argStringPtr(input, "viewName"); viewName != nil {
+				if _, err := s.searchDomain(*viewName); err != nil {
+					return nil, err
+				}
+				view = object{"name": *viewName}
+			}
+
+			s.blockedQueries = append(s.blockedQueries, object{
+				"id":                    s.newID(),
+				"pattern":               argString(input, "pattern"),
+				"type":                  argString(input, "type"),
+				"expiresAt":             nil,
+				"view":                  view,
+				"limitedToOrganization": !argBool(input, "clusterWide"),
+				"unblockAllowed":        true,
+			})
+			return s.blockedQueries, nil
+		}),
+		"removeFromBlocklist": resolver(func(args map[string]interface{}) (interface{}, error) {
+			var err error
+			if s.blockedQueries, err = removeByID(s.blockedQueries, "blocked query", argString(argObject(args, "input"), "id")); err != nil {
+				return nil, err
+			}
+			return true, nil
+		}),
+		"addOrUpdateQueryQuotaDefaultSettings": resolver(func(args map[string]interface{}) (interface{}, error) {
+			s.queryQuotaDefaults = updateQueryQuotaSettings(s.queryQuotaDefaults, argList(argObject(args, "input"), "settings"))
+			return object{"settings": queryQuotaSettings(s.queryQuotaDefaults, "Default")}, nil
+		}),
+		"addOrUpdateQueryQuotaUserSettings": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			username := argString(input, "username")
+			s.queryQuotaUsers[username] = updateQueryQuotaSettings(s.queryQuotaUsers[username], argList(input, "settings"))
+			return object{"username": username, "settings": queryQuotaSettings(s.queryQuotaUsers[username], "UserSpecified")}, nil
+		}),
+		"removeQueryQuotaUserSettings": resolver(func(args map[string]interface{}) (interface{}, error) {
+			username := argString(args, "username")
+			if _, ok := s.queryQuotaUsers[username]; !ok {
+				return nil, errNotFound("query quota settings of user", username)
+			}
+			delete(s.queryQuotaUsers, username)
+			return true, nil
+		}),
+		"addGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, group := range s.groups {
+				if group["displayName"] == argString(args, "displayName") {
+					return nil, fmt.Errorf("group %q already exists", group["displayName"])
+				}
+			}
+			return object{"group": s.addGroup(argString(args, "displayName"), argStringPtr(args, "lookupName"))}, nil
+		}),
+		"updateGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			group, err := s.group(argString(input, "groupId"))
+			if err != nil {
+				return nil, err
+			}
+			for _, field := range []string{"displayName", "lookupName"} {
+				if v, ok := input[field]; ok && v != nil {
+					group[field] = v
+				}
+			}
+			return object{"group": group}, nil
+		}),
+		"removeGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			group, err := s.group(argString(args, "groupId"))
+			if err != nil {
+				return nil, err
+			}
+			s.groups, _ = removeByID(s.groups, "group", argString(args, "groupId"))
+			return object{"group": group}, nil
+		}),
+		"addUsersToGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			group, err := s.group(argString(input, "groupId"))
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range argStrings(input, "users") {
+				user, err := s.userByID(id)
+				if err != nil {
+					return nil, err
+				}
+				group["users"] = append(group["users"].([]object), user)
+			}
+			return object{"group": group}, nil
+		}),
+		"removeUsersFromGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			group, err := s.group(argString(input, "groupId"))
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range argStrings(input, "users") {
+				if group["users"], err = removeByID(group["users"].([]object), "group member", id); err != nil {
+					return nil, err
+				}
+			}
+			return object{"group": group}, nil
+		}),
+		"assignRoleToGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			group, role, err := s.groupAndRole(input)
+			if err != nil {
+				return nil, err
+			}
+			d, err := s.searchDomainByID(argString(input, "viewId"))
+			if err != nil {
+				return nil, err
+			}
+			group["roles"] = append(group["roles"].([]object), s.searchDomainRole(d, role))
+			return object{"group": group}, nil
+		}),
+		"assignOrganizationRoleToGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			group, role, err := s.groupAndRole(argObject(args, "input"))
+			if err != nil {
+				return nil, err
+			}
+			group["organizationRoles"] = append(group["organizationRoles"].([]object), object{"role": role})
+			return object{"group": group}, nil
+		}),
+		"assignSystemRoleToGroup": resolver(func(args map[string]interface{}) (interface{}, error) {
+			group, role, err := s.groupAndRole(argObject(args, "input"))
+			if err != nil {
+				return nil, err
+			}
+			group["systemRoles"] = append(group["systemRoles"].([]object), object{"role": role})
+			return object{"group": group}, nil
+		}),
+		"createViewPermissionsToken": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			var views []object
+			for _, id := range argStrings(input, "viewIds") {
+				d, err := s.searchDomainByID(id)
+				if err != nil {
+					return nil, err
+				}
+				views = append(views, s.searchDomainObject(d))
+			}
+			return s.addToken("ViewPermissionsToken", input, views), nil
+		}),
+		"createOrganizationPermissionsToken": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.addToken("OrganizationPermissionsToken", argObject(args, "input"), nil), nil
+		}),
+		"createSystemPermissionsToken": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.addToken("SystemPermissionsToken", argObject(args, "input"), nil), nil
+		}),
+		"rotateToken": resolver(func(args map[string]interface{}) (interface{}, error) {
+			id := argString(argObject(args, "input"), "id")
+			for _, token := range s.tokens {
+				if token["id"] == id {
+					return tokenSecret(id, s.newID()), nil
+				}
+			}
+			return nil, errNotFound("token", id)
+		}),
+		"deleteToken": resolver(func(args map[string]interface{}) (interface{}, error) {
+			var err error
+			if s.tokens, err = removeByID(s.tokens, "token", argString(argObject(args, "input"), "id")); err != nil {
+				return nil, err
+			}
+			return true, nil
+		}),
+		"createParserV2": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "repositoryName"))
+			if err != nil {
+				return nil, err
+			}
+
+			parser := object{
+				"__typename":                     "Parser",
+				"name":                           argString(input, "name"),
+				"displayName":                    argString(input, "name"),
+				"isBuiltIn":                      false,
+				"script":                         argString(input, "script"),
+				"fieldsToTag":                    argStrings(input, "fieldsToTag"),
+				"fieldsToBeRemovedBeforeParsing": argStrings(input, "fieldsToBeRemovedBeforeParsing"),
+				"testCases":                      toObject(input["testCases"]),
+			}
+			parser["yamlTemplate"] = parserYAML(input)
+
+			for i, existing := range d.parsers {
+				if existing["name"] == parser["name"] {
+					if !argBool(input, "allowOverwritingExistingParser") {
+						return nil, fmt.Errorf("parser %q already exists", parser["name"])
+					}
+					parser["id"] = existing["id"]
+					d.parsers[i] = parser
+					return parser, nil
+				}
+			}
+			parser["id"] = s.newID()
+			d.parsers = append(d.parsers, parser)
+			return parser, nil
+		}),
+		"testParserV2": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			if _, err := s.searchDomain(argString(input, "repositoryName")); err != nil {
+				return nil, err
+			}
+
+			parse := s.Parse
+			if parse == nil {
+				parse = parseKeyValues
+			}
+
+			var results []object
+			for _, testCase := range argList(input, "testCases") {
+				testCase, _ := testCase.(map[string]interface{})
+				events, err := parse(argString(input, "script"), argString(argObject(testCase, "event"), "rawString"))
+				if err != nil {
+					return object{"__typename": "ParserTestRunAborted", "errorMessage": err.Error()}, nil
+				}
+				results = append(results, parserTestCaseResult(events, argList(testCase, "outputAssertions")))
+			}
+			return object{"__typename": "ParserTestRunCompleted", "results": results}, nil
+		}),
+		"deleteParser": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "repositoryName"))
+			if err != nil {
+				return nil, err
+			}
+			if d.parsers, err = removeByID(d.parsers, "parser", argString(input, "id")); err != nil {
+				return nil, err
+			}
+			return object{"result": true}, nil
+		}),
+		"deleteAction": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+			if d.actions, err = removeByID(d.actions, "action", argString(input, "id")); err != nil {
+				return nil, err
+			}
+			return true, nil
+		}),
+		"createAlert": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+
+			alert := object{"__typename": "Alert", "id": s.newID(), "isStarred": false, "enabled": true}
+			for _, field := range []string{"name", "description", "queryString", "queryStart", "throttleTimeMillis", "throttleField", "enabled", "actions", "labels"} {
+				if v, ok := input[field]; ok && v != nil {
+					alert[field] = v
+				}
+			}
+			if alert["labels"] == nil {
+				alert["labels"] = []string{}
+			}
+			alert["queryOwnership"] = s.queryOwnership(input)
+
+			d.alerts = append(d.alerts, alert)
+			return alert, nil
+		}),
+		"createFilterAlert": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+
+			var actions []object
+			for _, idOrName := range argStrings(input, "actionIdsOrNames") {
+				action, err := findAction(d, idOrName)
+				if err != nil {
+					return nil, err
+				}
+				actions = append(actions, action)
+			}
+
+			filterAlert := object{"__typename": "FilterAlert", "id": s.newID(), "actions": actions}
+			for _, field := range []string{"name", "description", "queryString", "throttleTimeSeconds", "throttleField", "enabled", "labels"} {
+				filterAlert[field] = input[field]
+			}
+			filterAlert["queryOwnership"] = s.queryOwnership(input)
+
+			d.filterAlerts = append(d.filterAlerts, filterAlert)
+			return filterAlert, nil
+		}),
+		"createSavedQuery": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+
+			var options interface{} = map[string]interface{}{}
+			if encoded := argStringPtr(input, "options"); encoded != nil {
+				if err := json.Unmarshal([]byte(*encoded), &options); err != nil {
+					return nil, fmt.Errorf("invalid options: %w", err)
+				}
+			}
+			start, end := "24h", "now"
+			if v := argStringPtr(input, "start"); v != nil {
+				start = *v
+			}
+			if v := argStringPtr(input, "end"); v != nil {
+				end = *v
+			}
+			widgetType := "list-view"
+			if v := argStringPtr(input, "widgetType"); v != nil {
+				widgetType = *v
+			}
+
+			savedQuery := object{
+				"id":         s.newID(),
+				"name":       argString(input, "name"),
+				"widgetType": widgetType,
+				"options":    options,
+				"query": object{
+					"queryString": argString(input, "queryString"),
+					"start":       start,
+					"end":         end,
+					"isLive":      argBool(input, "isLive"),
+				},
+			}
+
+			d.savedQueries = append(d.savedQueries, savedQuery)
+			return object{"savedQuery": savedQuery}, nil
+		}),
+		"deleteSavedQuery": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+			if d.savedQueries, err = removeByID(d.savedQueries, "saved query", argString(input, "id")); err != nil {
+				return nil, err
+			}
+			return object{"result": true}, nil
+		}),
+		"createDashboardFromTemplateV2": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+
+			templateYaml := argString(input, "yamlTemplate")
+			var template struct {
+				Description *string                `yaml:"description"`
+				Labels      []string               `yaml:"labels"`
+				Widgets     map[string]interface{} `yaml:"widgets"`
+				Parameters  map[string]interface{} `yaml:"parameters"`
+			}
+			if err := yaml.Unmarshal([]byte(templateYaml), &template); err != nil {
+				return nil, fmt.Errorf("invalid dashboard template: %w", err)
+			}
+
+			widgets := []object{}
+			for _, id := range sortedKeys(template.Widgets) {
+				widgets = append(widgets, object{"__typename": "UnknownWidget", "id": id})
+			}
+			parameters := []object{}
+			for _, id := range sortedKeys(template.Parameters) {
+				parameters = append(parameters, object{"__typename": "FreeTextDashboardParameter", "id": id})
+			}
+			labels := template.Labels
+			if labels == nil {
+				labels = []string{}
+			}
+
+			dashboard := object{
+				"id":           s.newID(),
+				"name":         argString(input, "name"),
+				"description":  template.Description,
+				"labels":       labels,
+				"widgets":      widgets,
+				"parameters":   parameters,
+				"templateYaml": templateYaml,
+			}
+			d.dashboards = append(d.dashboards, dashboard)
+			return dashboard, nil
+		}),
+		"deleteDashboard": resolver(func(args map[string]interface{}) (interface{}, error) {
+			id := argString(argObject(args, "input"), "id")
+			for _, d := range s.searchDomains {
+				for _, dashboard := range d.dashboards {
+					if dashboard["id"] == id {
+						d.dashboards, _ = removeByID(d.dashboards, "dashboard", id)
+						return object{"dashboard": dashboard}, nil
+					}
+				}
+			}
+			return nil, errNotFound("dashboard", id)
+		}),
+		"deleteAlert": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+			if d.alerts, err = removeByID(d.alerts, "alert", argString(input, "id")); err != nil {
+				return nil, err
+			}
+			return true, nil
+		}),
+		"enableAlert": resolver(func(args map[string]interface{}) (interface{}, error) {
+			if _, err := s.updateAlert(argObject(args, "input"), func(alert object) { alert["enabled"] = true }); err != nil {
+				return nil, err
+			}
+			return true, nil
+		}),
+		"disableAlert": resolver(func(args map[string]interface{}) (interface{}, error) {
+			if _, err := s.updateAlert(argObject(args, "input"), func(alert object) { alert["enabled"] = false }); err != nil {
+				return nil, err
+			}
+			return true, nil
+		}),
+		"clearErrorOnAlert": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.updateAlert(argObject(args, "input"), func(alert object) { alert["lastError"] = nil })
+		}),
+		"addAlertLabelV2": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			return s.updateAlert(input, func(alert object) {
+				alert["labels"] = append(toStrings(alert["labels"]), argString(input, "label"))
+			})
+		}),
+		"removeAlertLabelV2": resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			return s.updateAlert(input, func(alert object) {
+				var labels []string
+				for _, label := range toStrings(alert["labels"]) {
+					if label != argString(input, "label") {
+						labels = append(labels, label)
+					}
+				}
+				alert["labels"] = labels
+			})
+		}),
+	}
+
+	for mutation, typeName := range actionTypes {
+		typeName := typeName
+		root[mutation] = resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			d, err := s.searchDomain(argString(input, "viewName"))
+			if err != nil {
+				return nil, err
+			}
+
+			action := object{"__typename": typeName, "id": s.newID()}
+			for field, value := range input {
+				if field != "viewName" {
+					action[field] = toObject(value)
+				}
+			}
+			for _, existing := range d.actions {
+				if existing["name"] == action["name"] {
+					return nil, fmt.Errorf("action %q already exists", action["name"])
+				}
+			}
+
+			d.actions = append(d.actions, action)
+			return action, nil
+		})
+
+		root["test"+strings.TrimPrefix(mutation, "create")] = resolver(func(args map[string]interface{}) (interface{}, error) {
+			input := argObject(args, "input")
+			if _, err := s.searchDomain(argString(input, "viewName")); err != nil {
+				return nil, err
+			}
+
+			success, message := true, "Action triggered"
+			if s.TestAction != nil {
+				success, message = s.TestAction(typeName, input)
+			}
+			return object{"success": success, "message": message}, nil
+		})
+	}
+
+	return root
+}
+
+func (s *Server) searchDomain(name string) (*searchDomain, error) {
+	d, ok := s.searchDomains[name]
+	if !ok {
+		return nil, errNotFound("search domain", name)
+	}
+	return d, nil
+}
+
+func (s *Server) searchDomainByID(id string) (*searchDomain, error) {
+	for _, d := range s.searchDomains {
+		if d.id == id {
+			return d, nil
+		}
+	}
+	return nil, errNotFound("search domain", id)
+}
+
+func (s *Server) searchDomainNames() []string {
+	names := make([]string, 0, len(s.searchDomains))
+	for name := range s.searchDomains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Server) addSearchDomain(name string, description *string, isRepository bool, connections []viewConnection) (*searchDomain, error) {
+	if _, exists := s.searchDomains[name]; exists {
+		return nil, fmt.Errorf("a search domain with the name %q already exists", name)
+	}
+
+	d := &searchDomain{
+		id:              s.newID(),
+		name:            name,
+		description:     description,
+		automaticSearch: true,
+		isRepository:    isRepository,
+		retention:       map[string]interface{}{},
+		connections:     connections,
+	}
+	s.searchDomains[name] = d
+	return d, nil
+}
+
+func (s *Server) addUser(input map[string]interface{}) object {
+	user := object{
+		"__typename": "User",
+		"id":         s.newID(),
+		"isRoot":     false,
+		"createdAt":  time.Now().UTC().Format(time.RFC3339),
+
+		"searchDomainRoles": []object{},
+	}
+	for _, field := range []string{"username", "company", "isRoot", "fullName", "picture", "email", "countryCode"} {
+		if v, ok := input[field]; ok && v != nil {
+			user[field] = v
+		}
+	}
+	s.users[argString(input, "username")] = user
+	return user
+}
+
+func (s *Server) addGroup(name string, lookupName *string) object {
+	group := object{
+		"id":                s.newID(),
+		"displayName":       name,
+		"lookupName":        lookupName,
+		"users":             []object{},
+		"roles":             []object{},
+		"organizationRoles": []object{},
+		"systemRoles":       []object{},
+	}
+	s.groups = append(s.groups, group)
+	return group
+}
+
+func (s *Server) group(id string) (object, error) {
+	for _, group := range s.groups {
+		if group["id"] == id {
+			return group, nil
+		}
+	}
+	return nil, errNotFound("group", id)
+}
+
+func (s *Server) role(id string) (object, error) {
+	for _, role := range s.roles {
+		if role["id"] == id {
+			return role, nil
+		}
+	}
+	return nil, errNotFound("role", id)
+}
+
+// groupAndRole returns the group and the role given by the groupId and roleId of a role assignment input.
+func (s *Server) groupAndRole(input map[string]interface{}) (object, object, error) {
+	group, err := s.group(argString(input, "groupId"))
+	if err != nil {
+		return nil, nil, err
+	}
+	role, err := s.role(argString(input, "roleId"))
+	return group, role, err
+}
+
+// searchDomainRole returns the assignment of a role for a repository or view, as listed for users and groups.
+func (s *Server) searchDomainRole(d *searchDomain, role object) object {
+	return object{"searchDomain": s.searchDomainObject(d), "role": role}
+}
+
+func (s *Server) userByID(id string) (object, error) {
+	for _, user := range s.users {
+		if user["id"] == id {
+			return user, nil
+		}
+	}
+	return nil, errNotFound("user", id)
+}
+
+// addToken creates an API token of the given type from the input of a create mutation, and returns its secret.
+func (s *Server) addToken(typeName string, input map[string]interface{}, views []object) string {
+	token := object{
+		"__typename":  typeName,
+		"id":          s.newID(),
+		"name":        argString(input, "name"),
+		"expireAt":    input["expireAt"],
+		"createdAt":   time.Now().UnixMilli(),
+		"permissions": toStrings(input["permissions"]),
+	}
+	if typeName == "ViewPermissionsToken" {
+		token["views"] = views
+	}
+	s.tokens = append(s.tokens, token)
+	return tokenSecret(token["id"].(string), s.newID())
+}
+
+// tokenSecret returns a secret of the form used by LogScale, the ID of the token followed by a part
+// unique to each secret.
+func tokenSecret(id, unique string) string {
+	return id + "~" + unique
+}
+
+func (s *Server) queryOwnership(input map[string]interface{}) object {
+	if argString(input, "queryOwnershipType") == "Organization" {
+		return object{"__typename": "OrganizationOwnership", "id": "organization"}
+	}
+
+	id := argString(input, "runAsUserId")
+	if id == "" {
+		if viewer, ok := s.users[s.Viewer]; ok {
+			id, _ = viewer["id"].(string)
+		}
+	}
+	return object{"__typename": "UserOwnership", "id": id}
+}
+
+func (s *Server) searchDomainObject(d *searchDomain) object {
+	typeName := "View"
+	if d.isRepository {
+		typeName = "Repository"
+	}
+
+	connections := make([]object, len(d.connections))
+	for i, connection := range d.connections {
+		connections[i] = object{
+			"repository": object{"__typename": "Repository", "name": connection.repositoryName},
+			"filter":     connection.filter,
+		}
+	}
+
+	obj := object{
+		"__typename":         typeName,
+		"id":                 d.id,
+		"name":               d.name,
+		"description":        d.description,
+		"automaticSearch":    d.automaticSearch,
+		"compressedByteSize": 0,
+		"connections":        connections,
+		"parsers":            d.parsers,
+		"actions":            d.actions,
+		"alerts":             d.alerts,
+		"savedQueries":       d.savedQueries,
+		"dashboards":         d.dashboards,
+		"filterAlerts":       d.filterAlerts,
+		"aggregateAlerts":    []object{},
+		"scheduledSearches":  []object{},
+		"parser": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, parser := range d.parsers {
+				if parser["id"] == args["id"] || parser["name"] == args["name"] {
+					return parser, nil
+				}
+			}
+			return nil, errNotFound("parser", fmt.Sprint(args["name"]))
+		}),
+		"dashboard": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, dashboard := range d.dashboards {
+				if dashboard["id"] == args["id"] {
+					return dashboard, nil
+				}
+			}
+			return nil, errNotFound("dashboard", argString(args, "id"))
+		}),
+		"action": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, action := range d.actions {
+				if action["id"] == args["id"] {
+					return action, nil
+				}
+			}
+			return nil, errNotFound("action", argString(args, "id"))
+		}),
+	}
+	for field, value := range d.retention {
+		obj[field] = value
+	}
+	return obj
+}
+
+func viewConnections(list []interface{}) []viewConnection {
+	connections := make([]viewConnection, 0, len(list))
+	for _, v := range list {
+		connection, _ := v.(map[string]interface{})
+		connections = append(connections, viewConnection{
+			repositoryName: argString(connection, "repositoryName"),
+			filter:         argString(connection, "filter"),
+		})
+	}
+	return connections
+}
+
+// updateAlert applies update to the alert given by the viewName and id of input, and returns the alert.
+func (s *Server) updateAlert(input map[string]interface{}, update func(alert object)) (object, error) {
+	d, err := s.searchDomain(argString(input, "viewName"))
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range d.alerts {
+		if alert["id"] == argString(input, "id") {
+			update(alert)
+			return alert, nil
+		}
+	}
+	return nil, errNotFound("alert", argString(input, "id"))
+}
+
+// analyzeQuery imitates the query analysis of LogScale: queries with unbalanced parentheses are invalid,
+// queries using sort, head or tail can only be used by legacy alerts, queries using an aggregate function
+// suit aggregate alerts, and all other queries suit filter alerts.
+func analyzeQuery(queryString string) object {
+	if strings.Count(queryString, "(") != strings.Count(queryString, ")") {
+		return object{
+			"validateQuery": object{
+				"isValid":     false,
+				"diagnostics": []object{{"message": "unbalanced parentheses", "severity": "Error"}},
+			},
+			"suggestedAlertType": nil,
+		}
+	}
+
+	analysis := object{
+		"validateQuery":      object{"isValid": true, "diagnostics": []object{}},
+		"suggestedAlertType": object{"alertType": "FilterAlert"},
+	}
+	for _, function := range []string{"sort(", "head(", "tail("} {
+		if strings.Contains(queryString, function) {
+			analysis["suggestedAlertType"] = nil
+			return analysis
+		}
+	}
+	for _, function := range []string{"count(", "groupBy(", "sum(", "avg(", "min(", "max(", "top("} {
+		if strings.Contains(queryString, function) {
+			analysis["suggestedAlertType"] = object{"alertType": "AggregateAlert"}
+		}
+	}
+	return analysis
+}
+
+// updateQueryQuotaSettings adds or replaces the settings for the intervals and measurements of inputs.
+func updateQueryQuotaSettings(settings []object, inputs []interface{}) []object {
+	for _, input := range inputs {
+		input, _ := input.(map[string]interface{})
+		setting, _ := toObject(input).(object)
+
+		replaced := false
+		for i, existing := range settings {
+			if existing["interval"] == setting["interval"] && existing["measurementKind"] == setting["measurementKind"] {
+				settings[i], replaced = setting, true
+			}
+		}
+		if !replaced {
+			settings = append(settings, setting)
+		}
+	}
+	return settings
+}
+
+// queryQuotaSettings returns the stored settings with the given source.
+func queryQuotaSettings(settings []object, source string) []object {
+	list := make([]object, len(settings))
+	for i, setting := range settings {
+		list[i] = object{"source": source}
+		for field, value := range setting {
+			list[i][field] = value
+		}
+	}
+	return list
+}
+
+// toStrings returns a list of strings stored in an object, whether created from input or by a resolver.
+func toStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		strings := make([]string, len(v))
+		for i, s := range v {
+			strings[i] = fmt.Sprint(s)
+		}
+		return strings
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// findAction returns the action of a search domain with the given ID or name.
+func findAction(d *searchDomain, idOrName string) (object, error) {
+	for _, action := range d.actions {
+		if action["id"] == idOrName || action["name"] == idOrName {
+			return action, nil
+		}
+	}
+	return nil, errNotFound("action", idOrName)
+}
+
+func removeByID(list []object, entity, id string) ([]object, error) {
+	for i, obj := range list {
+		if obj["id"] == id {
+			return append(list[:i], list[i+1:]...), nil
+		}
+	}
+	return list, errNotFound(entity, id)
+}
+
+// toObject converts decoded input values to objects, so they can be returned in query results.
+func toObject(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		obj := object{}
+		for key, value := range v {
+			obj[key] = toObject(value)
+		}
+		return obj
+	case []interface{}:
+		if len(v) > 0 {
+			if _, ok := v[0].(map[string]interface{}); ok {
+				list := make([]object, len(v))
+				for i := range v {
+					list[i], _ = toObject(v[i]).(object)
+				}
+				return list
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func setRoleFields(role object, input map[string]interface{}) {
+	role["displayName"] = argString(input, "displayName")
+	role["viewPermissions"] = argStrings(input, "viewPermissions")
+	role["organizationPermissions"] = argStrings(input, "organizationPermissions")
+	role["systemPermissions"] = argStrings(input, "systemPermissions")
+}
+
+// parseKeyValues is the default ParseFunc, which parses key=value pairs separated by whitespace.
+func parseKeyValues(_, rawString string) ([]map[string]string, error) {
+	event := map[string]string{"@rawstring": rawString}
+	for _, pair := range strings.Fields(rawString) {
+		if key, value, ok := strings.Cut(pair, "="); ok && key != "" {
+			event[key] = value
+		}
+	}
+	return []map[string]string{event}, nil
+}
+
+// parserTestCaseResult checks the output assertions of a test case against the events the parser produced.
+func parserTestCaseResult(events []map[string]string, outputAssertions []interface{}) object {
+	outputEvents := make([]object, len(events))
+	for i, event := range events {
+		names := make([]string, 0, len(event))
+		for name := range event {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := make([]object, len(names))
+		for j, name := range names {
+			fields[j] = object{"fieldName": name, "value": event[name]}
+		}
+		outputEvents[i] = object{"fields": fields}
+	}
+
+	outputFailures := []object{}
+	for _, outputAssertion := range outputAssertions {
+		outputAssertion, _ := outputAssertion.(map[string]interface{})
+		index := argInt(outputAssertion, "outputEventIndex")
+		assertions := argObject(outputAssertion, "assertions")
+
+		var event map[string]string
+		if index >= 0 && index < len(events) {
+			event = events[index]
+		}
+
+		failures := []object{}
+		for _, name := range argStrings(assertions, "fieldsNotPresent") {
+			switch value, ok := event[name]; {
+			case event == nil:
+				failures = append(failures, object{"__typename": "AssertionOnFieldWasOrphaned", "fieldName": name})
+			case ok:
+				failures = append(failures, object{"__typename": "FieldUnexpectedlyPresent", "fieldName": name, "actualValue": value})
+			}
+		}
+		for _, fieldHasValue := range argList(assertions, "fieldsHaveValues") {
+			fieldHasValue, _ := fieldHasValue.(map[string]interface{})
+			name, expected := argString(fieldHasValue, "fieldName"), argString(fieldHasValue, "expectedValue")
+			switch value, ok := event[name]; {
+			case event == nil:
+				failures = append(failures, object{"__typename": "AssertionOnFieldWasOrphaned", "fieldName": name})
+			case !ok:
+				failures = append(failures, object{"__typename": "FieldHadUnexpectedValue", "fieldName": name, "expectedValue": expected, "actualValue": nil})
+			case value != expected:
+				failures = append(failures, object{"__typename": "FieldHadUnexpectedValue", "fieldName": name, "expectedValue": expected, "actualValue": value})
+			}
+		}
+
+		if len(failures) > 0 {
+			outputFailures = append(outputFailures, object{
+				"outputEventIndex": index,
+				"failures": object{
+					"parsingErrors":             []string{},
+					"assertionFailuresOnFields": failures,
+					"falselyTaggedFields":       []string{},
+					"arraysWithGaps":            []object{},
+				},
+			})
+		}
+	}
+
+	return object{"outputEvents": outputEvents, "outputFailures": outputFailures}
+}
+
+func parserYAML(input map[string]interface{}) string {
+	data, err := yaml.Marshal(map[string]interface{}{
+		"name":      argString(input, "name"),
+		"script":    argString(input, "script"),
+		"tagFields": argStrings(input, "fieldsToTag"),
+		"testCases": input["testCases"],
+	})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,425 @@
+// Package fakelogscale provides an in-process fake of the LogScale HTTP and GraphQL APIs for tests.
+//
+// The GraphQL endpoint validates requests against the schema in internal/api/humiographql/schema, which is
+// read from the source tree, and keeps repositories, views, parsers, actions, alerts, filter alerts, saved queries,
+// dashboards, users, groups, roles, API tokens, the query blocklist, query quotas and query jobs in memory.
+package fakelogscale
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultVersion is the LogScale version reported by the status endpoint unless Server.Version is changed.
+const DefaultVersion = "1.150.0"
+
+// QueryFunc evaluates a query string against the events of a search domain.
+// It returns the resulting events, and whether the result should be presented as an aggregate.
+type QueryFunc func(queryString string, events []map[string]interface{}) (result []map[string]interface{}, isAggregate bool)
+
+// ParseFunc runs a parser script on the raw string of an event, and returns the output events.
+// An error aborts the parser test run with its message.
+type ParseFunc func(script, rawString string) ([]map[string]string, error)
+
+// ActionTestFunc decides the outcome of testing an action of the given type, with the input of the test mutation.
+type ActionTestFunc func(typeName string, input map[string]interface{}) (success bool, message string)
+
+// Server is a fake LogScale cluster. Create it with NewServer and stop it with Close.
+type Server struct {
+	*httptest.Server
+
+	// Version is the version reported by api/v1/status.
+	Version string
+	// Token, if set, must be sent as a bearer token on every request.
+	Token string
+	// Query evaluates query jobs on the events of the search domain within the time range of the query.
+	// By default, all of those events are returned.
+	Query QueryFunc
+	// Viewer is the username returned for the authenticated user.
+	Viewer string
+	// TestAction decides the outcome of action tests. By default, all tests succeed.
+	TestAction ActionTestFunc
+	// Parse runs parser scripts in parser tests. By default, each event is parsed into a single output event
+	// with its @rawstring and the key=value pairs of the raw string.
+	Parse ParseFunc
+
+	mu            sync.Mutex
+	nextID        int
+	searchDomains map[string]*searchDomain
+	users         map[string]object
+	roles         []object
+	groups        []object
+	tokens        []object
+	queryJobs     map[string]*queryJob
+
+	blockedQueries     []object
+	queryQuotaDefaults []object
+	queryQuotaUsers    map[string][]object
+}
+
+type searchDomain struct {
+	id              string
+	name            string
+	description     *string
+	automaticSearch bool
+	isRepository    bool
+	retention       map[string]interface{}
+	connections     []viewConnection
+	parsers         []object
+	actions         []object
+	alerts          []object
+	filterAlerts    []object
+	savedQueries    []object
+	dashboards      []object
+	events          []map[string]interface{}
+}
+
+type viewConnection struct {
+	repositoryName string
+	filter         string
+}
+
+type queryJob struct {
+	searchDomain string
+	events       []map[string]interface{}
+	isAggregate  bool
+}
+
+// NewServer starts a fake LogScale server with no data.
+func NewServer() *Server {
+	s := &Server{
+		Version:       DefaultVersion,
+		Viewer:        "admin",
+		searchDomains: map[string]*searchDomain{},
+		users:         map[string]object{},
+		queryJobs:     map[string]*queryJob{},
+
+		queryQuotaUsers: map[string][]object{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddRepository creates an empty repository.
+func (s *Server) AddRepository(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addSearchDomain(name, nil, true, nil)
+}
+
+// AddView creates a view searching the given repositories without a filter.
+func (s *Server) AddView(name string, repositoryNames ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	connections := make([]viewConnection, len(repositoryNames))
+	for i, repositoryName := range repositoryNames {
+		connections[i] = viewConnection{repositoryName: repositoryName}
+	}
+	s.addSearchDomain(name, nil, false, connections)
+}
+
+// AddUser creates a user.
+func (s *Server) AddUser(username string, isRoot bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(map[string]interface{}{"username": username, "isRoot": isRoot})
+}
+
+// AddGroup creates a group with the given existing users as members.
+func (s *Server) AddGroup(name string, usernames ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group := s.addGroup(name, nil)
+	for _, username := range usernames {
+		user, ok := s.users[username]
+		if !ok {
+			return errNotFound("user", username)
+		}
+		group["users"] = append(group["users"].([]object), user)
+	}
+	return nil
+}
+
+// AssignUserRole assigns a role directly to a user for a repository or view, which humioctl does not do.
+func (s *Server) AssignUserRole(username, searchDomainName, roleName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[username]
+	if !ok {
+		return errNotFound("user", username)
+	}
+	d, err := s.searchDomain(searchDomainName)
+	if err != nil {
+		return err
+	}
+	for _, role := range s.roles {
+		if role["displayName"] == roleName {
+			user["searchDomainRoles"] = append(user["searchDomainRoles"].([]object), s.searchDomainRole(d, role))
+			return nil
+		}
+	}
+	return errNotFound("role", roleName)
+}
+
+// AddToken creates an organization API token with the given permissions, and returns its secret.
+func (s *Server) AddToken(name string, permissions ...string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addToken("OrganizationPermissionsToken", map[string]interface{}{"name": name, "permissions": permissions}, nil)
+}
+
+// AddEvents appends events to a repository, making them visible to query jobs.
+func (s *Server) AddEvents(repositoryName string, events ...map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.searchDomains[repositoryName]
+	if !ok || !d.isRepository {
+		return errNotFound("repository", repositoryName)
+	}
+	d.events = append(d.events, events...)
+	return nil
+}
+
+// SetSavedQueryDescription sets the description of a saved query, which cannot be done through the API.
+func (s *Server) SetSavedQueryDescription(viewName, savedQueryName, description string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.searchDomains[viewName]
+	if !ok {
+		return errNotFound("search domain", viewName)
+	}
+	for _, savedQuery := range d.savedQueries {
+		if savedQuery["name"] == savedQueryName {
+			savedQuery["description"] = description
+			return nil
+		}
+	}
+	return errNotFound("saved query", savedQueryName)
+}
+
+// SetAlertError puts an alert into an error state, as if its last run had failed.
+func (s *Server) SetAlertError(searchDomainName, alertName, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.searchDomains[searchDomainName]
+	if !ok {
+		return errNotFound("search domain", searchDomainName)
+	}
+	for _, alert := range d.alerts {
+		if alert["name"] == alertName {
+			alert["lastError"] = lastError
+			return nil
+		}
+	}
+	return errNotFound("alert", alertName)
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%08d", s.nextID)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		http.Error(w, "The supplied authentication token is invalid", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case path == "graphql" && r.Method == http.MethodPost:
+		s.serveGraphQL(w, r)
+	case path == "api/v1/status" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]string{"status": "OK", "version": s.Version})
+	case strings.HasPrefix(path, "api/v1/repositories/"):
+		s.serveQueryJobs(w, r, strings.Split(strings.TrimPrefix(path, "api/v1/repositories/"), "/"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	schema, err := loadSchema()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, execute(schema, req, s.queryRoot(), s.mutationRoot()))
+}
+
+func (s *Server) serveQueryJobs(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 || parts[1] != "queryjobs" {
+		http.NotFound(w, r)
+		return
+	}
+
+	name := parts[0]
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		d, ok := s.searchDomains[name]
+		if !ok {
+			http.Error(w, errNotFound("search domain", name).Error(), http.StatusNotFound)
+			return
+		}
+
+		var body struct {
+			QueryString string      `json:"queryString"`
+			Start       interface{} `json:"start"`
+			End         interface{} `json:"end"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		now := time.Now()
+		start, err := queryTime(body.Start, now)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		end, err := queryTime(body.End, now)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		query := s.Query
+		if query == nil {
+			query = func(_ string, events []map[string]interface{}) ([]map[string]interface{}, bool) {
+				return events, false
+			}
+		}
+		events, isAggregate := query(body.QueryString, eventsBetween(s.searchDomainEvents(d), start, end))
+
+		id := s.newID()
+		s.queryJobs[id] = &queryJob{searchDomain: name, events: events, isAggregate: isAggregate}
+		writeJSON(w, http.StatusOK, map[string]string{"id": id})
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		job, ok := s.queryJobs[parts[2]]
+		if !ok || job.searchDomain != name {
+			http.NotFound(w, r)
+			return
+		}
+
+		events := job.events
+		if events == nil {
+			events = []map[string]interface{}{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"done":      true,
+			"cancelled": false,
+			"events":    events,
+			"metaData": map[string]interface{}{
+				"eventCount":  len(events),
+				"isAggregate": job.isAggregate,
+				"pollAfter":   0,
+				"queryEnd":    time.Now().UnixMilli(),
+				"totalWork":   1,
+				"workDone":    1,
+			},
+		})
+	case len(parts) == 3 && r.Method == http.MethodDelete:
+		delete(s.queryJobs, parts[2])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) searchDomainEvents(d *searchDomain) []map[string]interface{} {
+	if d.isRepository {
+		return append([]map[string]interface{}{}, d.events...)
+	}
+
+	var events []map[string]interface{}
+	for _, connection := range d.connections {
+		if repository, ok := s.searchDomains[connection.repositoryName]; ok {
+			events = append(events, repository.events...)
+		}
+	}
+	return events
+}
+
+var relativeTimePattern = regexp.MustCompile(`^\s*(\d+)\s*([a-zA-Z]+)\s*$`)
+
+// queryTime parses the start or end of a query, given in milliseconds since the epoch or relative to now.
+// It returns nil if the time is not given.
+func queryTime(v interface{}, now time.Time) (*time.Time, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		t := time.UnixMilli(int64(v))
+		return &t, nil
+	case string:
+		if v == "now" {
+			return &now, nil
+		}
+		match := relativeTimePattern.FindStringSubmatch(v)
+		if match == nil {
+			return nil, fmt.Errorf("invalid relative time %q", v)
+		}
+		n, _ := strconv.Atoi(match[1])
+		units := map[string]time.Duration{
+			"s": time.Second, "seconds": time.Second, "m": time.Minute, "minutes": time.Minute,
+			"h": time.Hour, "hours": time.Hour, "d": 24 * time.Hour, "days": 24 * time.Hour,
+			"w": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+		}
+		unit, ok := units[strings.ToLower(match[2])]
+		if !ok {
+			return nil, fmt.Errorf("invalid relative time %q", v)
+		}
+		t := now.Add(-time.Duration(n) * unit)
+		return &t, nil
+	default:
+		return nil, fmt.Errorf("invalid query time %v", v)
+	}
+}
+
+// eventsBetween returns the events with an @timestamp from start up to end. Events without a numeric
+// @timestamp are always included.
+func eventsBetween(events []map[string]interface{}, start, end *time.Time) []map[string]interface{} {
+	var filtered []map[string]interface{}
+	for _, event := range events {
+		var millis int64
+		switch timestamp := event["@timestamp"].(type) {
+		case int:
+			millis = int64(timestamp)
+		case int64:
+			millis = timestamp
+		case float64:
+			millis = int64(timestamp)
+		default:
+			filtered = append(filtered, event)
+			continue
+		}
+		if start != nil && millis < start.UnixMilli() || end != nil && millis >= end.UnixMilli() {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	return filtered
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,228 @@
+package fakelogscale_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/humio/cli/internal/api"
+	"github.com/humio/cli/internal/fakelogscale"
+)
+
+func newTestClient(t *testing.T) (*fakelogscale.Server, *api.Client) {
+	t.Helper()
+
+	server := fakelogscale.NewServer()
+	t.Cleanup(server.Close)
+
+	address, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, api.NewClient(api.Config{Address: address})
+}
+
+func TestRepositoriesAndViews(t *testing.T) {
+	_, client := newTestClient(t)
+
+	if err := client.Repositories().Create("logs"); err != nil {
+		t.Fatalf("creating repository: %v", err)
+	}
+	if err := client.Views().Create("all", "All logs", []api.ViewConnectionInput{{RepositoryName: "logs", Filter: "*"}}); err != nil {
+		t.Fatalf("creating view: %v", err)
+	}
+
+	repos, err := client.Repositories().List()
+	if err != nil {
+		t.Fatalf("listing repositories: %v", err)
+	}
+	if len(repos) != 1 || repos[0].Name != "logs" {
+		t.Errorf("expected repository %q, got %+v", "logs", repos)
+	}
+
+	view, err := client.Views().Get("all")
+	if err != nil {
+		t.Fatalf("getting view: %v", err)
+	}
+	if view.Description != "All logs" || len(view.Connections) != 1 || view.Connections[0].RepoName != "logs" {
+		t.Errorf("unexpected view %+v", view)
+	}
+
+	if err := client.Views().Delete("all", "test"); err != nil {
+		t.Fatalf("deleting view: %v", err)
+	}
+	if _, err := client.Views().Get("all"); err == nil {
+		t.Error("expected view to be deleted")
+	}
+}
+
+func TestActionsAndAlerts(t *testing.T) {
+	server, client := newTestClient(t)
+	server.AddRepository("logs")
+	server.AddUser("admin", true)
+
+	action, err := client.Actions().Add("logs", &api.Action{
+		Name: "hook",
+		WebhookAction: api.WebhookAction{
+			Method:  "POST",
+			Url:     "https://example.com",
+			Headers: []api.HttpHeader{{Header: "X-Test", Value: "1"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("adding action: %v", err)
+	}
+
+	fetched, err := client.Actions().Get("logs", "hook")
+	if err != nil {
+		t.Fatalf("getting action: %v", err)
+	}
+	if fetched.ID != action.ID || fetched.Type != "WebhookAction" || len(fetched.WebhookAction.Headers) != 1 {
+		t.Errorf("unexpected action %+v", fetched)
+	}
+
+	_, err = client.Alerts().Add("logs", &api.Alert{
+		Name:               "errors",
+		QueryString:        "error",
+		QueryStart:         "1h",
+		ThrottleTimeMillis: 60000,
+		Actions:            []string{"hook"},
+		QueryOwnershipType: "User",
+	})
+	if err != nil {
+		t.Fatalf("adding alert: %v", err)
+	}
+	if err := client.Alerts().Delete("logs", "errors"); err != nil {
+		t.Fatalf("deleting alert: %v", err)
+	}
+	if err := client.Actions().Delete("logs", "hook"); err != nil {
+		t.Fatalf("deleting action: %v", err)
+	}
+
+	actions, err := client.Actions().List("logs")
+	if err != nil {
+		t.Fatalf("listing actions: %v", err)
+	}
+	if len(actions) != 0 {
+		t.Errorf("expected no actions, got %+v", actions)
+	}
+}
+
+func TestParsers(t *testing.T) {
+	server, client := newTestClient(t)
+	server.AddRepository("logs")
+
+	added, err := client.Parsers().Add("logs", &api.Parser{
+		Name:   "json",
+		Script: "parseJson()",
+		TestCases: []api.ParserTestCase{{
+			Event:      api.ParserTestEvent{RawString: `{"a": 1}`},
+			Assertions: []api.ParserTestCaseAssertions{{OutputEventIndex: 0, FieldsHaveValues: map[string]string{"a": "1"}}},
+		}},
+		FieldsToTag:                    []string{},
+		FieldsToBeRemovedBeforeParsing: []string{},
+	}, false)
+	if err != nil {
+		t.Fatalf("adding parser: %v", err)
+	}
+	if value := added.TestCases[0].Assertions[0].FieldsHaveValues["a"]; value != "1" {
+		t.Errorf("expected the added parser to keep the expected value of field a, got %q", value)
+	}
+
+	parser, err := client.Parsers().Get("logs", "json")
+	if err != nil {
+		t.Fatalf("getting parser: %v", err)
+	}
+	if parser.Script != "parseJson()" || len(parser.TestCases) != 1 {
+		t.Errorf("unexpected parser %+v", parser)
+	}
+	if value := parser.TestCases[0].Assertions[0].FieldsHaveValues["a"]; value != "1" {
+		t.Errorf("expected the expected value of field a, got %q", value)
+	}
+
+	if _, err := client.Parsers().Add("logs", &api.Parser{Name: "json", FieldsToTag: []string{}, FieldsToBeRemovedBeforeParsing: []string{}}, false); err == nil {
+		t.Error("expected adding a duplicate parser to fail")
+	}
+}
+
+func TestUsers(t *testing.T) {
+	_, client := newTestClient(t)
+
+	isRoot := true
+	if _, err := client.Users().Add("alice", &isRoot, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("adding user: %v", err)
+	}
+
+	user, err := client.Users().Get("alice")
+	if err != nil {
+		t.Fatalf("getting user: %v", err)
+	}
+	if !user.IsRoot {
+		t.Errorf("expected user to be root")
+	}
+
+	if _, err := client.Users().Remove("alice"); err != nil {
+		t.Fatalf("removing user: %v", err)
+	}
+	if _, err := client.Users().Get("alice"); err == nil {
+		t.Error("expected user to be removed")
+	}
+}
+
+func TestQueryJobs(t *testing.T) {
+	server, client := newTestClient(t)
+	server.AddRepository("logs")
+	server.AddView("all", "logs")
+	if err := server.AddEvents("logs", map[string]interface{}{"@rawstring": "hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := client.QueryJobs().Create("all", api.Query{QueryString: "*"})
+	if err != nil {
+		t.Fatalf("creating query job: %v", err)
+	}
+
+	result, err := client.QueryJobs().PollContext(context.Background(), "all", id)
+	if err != nil {
+		t.Fatalf("polling query job: %v", err)
+	}
+	if !result.Done || len(result.Events) != 1 || result.Events[0]["@rawstring"] != "hello" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	now := time.Now()
+	for _, age := range []time.Duration{30 * time.Minute, 2 * time.Hour} {
+		event := map[string]interface{}{"@rawstring": age.String(), "@timestamp": now.Add(-age).UnixMilli()}
+		if err := server.AddEvents("logs", event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	endMillis := now.Add(-time.Hour).UnixMilli()
+	for _, test := range []struct {
+		query    api.Query
+		expected []string
+	}{
+		{api.Query{QueryString: "*", Start: "1h"}, []string{"hello", "30m0s"}},
+		{api.Query{QueryString: "*", Start: "1d", EndMillis: &endMillis}, []string{"hello", "2h0m0s"}},
+	} {
+		id, err := client.QueryJobs().Create("all", test.query)
+		if err != nil {
+			t.Fatalf("creating query job: %v", err)
+		}
+		result, err := client.QueryJobs().PollContext(context.Background(), "all", id)
+		if err != nil {
+			t.Fatalf("polling query job: %v", err)
+		}
+		var rawStrings []string
+		for _, event := range result.Events {
+			rawStrings = append(rawStrings, fmt.Sprint(event["@rawstring"]))
+		}
+		if fmt.Sprint(rawStrings) != fmt.Sprint(test.expected) {
+			t.Errorf("expected events %v for start %q, got %v", test.expected, test.query.Start, rawStrings)
+		}
+	}
+}
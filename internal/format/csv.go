@@ -0,0 +1,34 @@
+package format
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVFormatter writes tables as delimiter-separated values with a header row, and details as name and value pairs.
+type CSVFormatter struct {
+	w         io.Writer
+	comma     rune
+	noHeaders bool
+}
+
+func (c CSVFormatter) Details(rows [][]Value) error {
+	w := c.writer()
+	return w.WriteAll(ValuesToStrings(rows))
+}
+
+func (c CSVFormatter) Table(header []string, rows [][]Value) error {
+	w := c.writer()
+	if !c.noHeaders {
+		if err := w.Write(header); err != nil {
+			return err
+		}
+	}
+	return w.WriteAll(ValuesToStrings(rows))
+}
+
+func (c CSVFormatter) writer() *csv.Writer {
+	w := csv.NewWriter(c.w)
+	w.Comma = c.comma
+	return w
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"strconv"
 	"strings"
 
@@ -11,37 +12,73 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func FormatterFromCommand(cmd *cobra.Command) Formatter {
-	factories := map[string]func(cmd *cobra.Command) Formatter{
-		"json": func(cmd *cobra.Command) Formatter { return JSONFormatter{cmd.OutOrStdout()} },
-	}
-
-	var formatter string
-
+// FormatterFromCommand returns the formatter selected with the --format flag of cmd.
+// The text table is used if no format is given.
+func FormatterFromCommand(cmd *cobra.Command) (Formatter, error) {
 	format := cmd.Flags().Lookup("format")
 	if format == nil {
-		panic("could not fetch format")
+		return nil, fmt.Errorf("command %q has no --format flag", cmd.Name())
 	}
 
+	var spec string
 	if value := format.Value; value != nil {
-		formatter = value.String()
+		spec = value.String()
 	}
-	factory := factories[formatter]
 
-	if factory == nil {
-		return TextFormatter{w: cmd.OutOrStdout()}
+	opts, err := OptionsFromCommand(cmd)
+	if err != nil {
+		return nil, err
 	}
 
-	return factory(cmd)
+	return NewFormatter(cmd.OutOrStdout(), spec, opts)
+}
+
+// NewFormatter returns the formatter writing to w described by spec, which is one of
+// json, yaml, csv, tsv, ndjson, template=<go-template>, template-file=<path>, jsonpath=<template> or jq=<expression>.
+// An empty spec selects the text table. Of the options, only NoHeaders is used by the formatter itself,
+// the others are applied to the data with Options.ApplyTable and Options.ApplyDetails.
+func NewFormatter(w io.Writer, spec string, opts Options) (Formatter, error) {
+	name, arg, _ := strings.Cut(spec, "=")
+
+	switch name {
+	case "", "text", "table":
+		return TextFormatter{w: w, noHeaders: opts.NoHeaders}, nil
+	case "json":
+		return JSONFormatter{w}, nil
+	case "yaml":
+		return YAMLFormatter{w}, nil
+	case "csv":
+		return CSVFormatter{w: w, comma: ',', noHeaders: opts.NoHeaders}, nil
+	case "tsv":
+		return CSVFormatter{w: w, comma: '\t', noHeaders: opts.NoHeaders}, nil
+	case "ndjson":
+		return NDJSONFormatter{w}, nil
+	case "template":
+		return NewTemplateFormatter(w, arg)
+	case "template-file":
+		// #nosec G304
+		text, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read template file: %w", err)
+		}
+		return NewTemplateFormatter(w, string(text))
+	case "jsonpath":
+		return NewJSONPathFormatter(w, arg)
+	case "jq":
+		return NewJQFormatter(w, arg)
+	default:
+		return nil, fmt.Errorf("unknown format %q, valid formats: json, yaml, csv, tsv, ndjson, template=<go-template>, template-file=<path>, jsonpath=<template>, jq=<expression>", spec)
+	}
 }
 
 type Formatter interface {
-	Details(rows [][]Value)
-	Table(header []string, rows [][]Value)
+	Details(rows [][]Value) error
+	Table(header []string, rows [][]Value) error
 }
 
 type TextFormatter struct {
-	w io.Writer
+	w         io.Writer
+	noHeaders bool
 }
 
 type Value interface {
@@ -143,6 +180,55 @@ func (f Float) MarshalJSON() ([]byte, error) {
 	return json.Marshal(float64(f))
 }
 
+// nativeValue returns v as a plain Go value, in the same shape as its JSON representation.
+func nativeValue(v Value) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var native interface{}
+	err = json.Unmarshal(data, &native)
+	return native, err
+}
+
+// detailsDocument returns the details as the plain Go value of their JSON representation, a map from field to value.
+func detailsDocument(rows [][]Value) (map[string]interface{}, error) {
+	var err error
+	m := make(map[string]interface{}, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		m[row[0].String()], err = nativeValue(row[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// tableDocument returns the rows as the plain Go value of their JSON representation, a list of maps from column to value.
+func tableDocument(header []string, rows [][]Value) ([]interface{}, error) {
+	var err error
+	m := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		r := make(map[string]interface{}, len(header))
+		for i := range header {
+			r[header[i]], err = nativeValue(row[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		m = append(m, r)
+	}
+	return m, nil
+}
+
 func ValuesToStrings(sliceOfValues [][]Value) [][]string {
 	var result [][]string
 	for _, values := range sliceOfValues {
@@ -159,27 +245,31 @@ func ValuesToStrings(sliceOfValues [][]Value) [][]string {
 	return result
 }
 
-func (t TextFormatter) Details(rows [][]Value) {
+func (t TextFormatter) Details(rows [][]Value) error {
 	w := tablewriter.NewWriter(t.w)
 	w.AppendBulk(ValuesToStrings(rows))
 	w.SetBorder(false)
 	w.SetColumnAlignment([]int{tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_LEFT})
 	w.Render()
+	return nil
 }
 
-func (t TextFormatter) Table(header []string, rows [][]Value) {
+func (t TextFormatter) Table(header []string, rows [][]Value) error {
 	w := tablewriter.NewWriter(t.w)
 	w.AppendBulk(ValuesToStrings(rows))
 	w.SetBorder(false)
-	w.SetHeader(header)
+	if !t.noHeaders {
+		w.SetHeader(header)
+	}
 	w.Render()
+	return nil
 }
 
 type JSONFormatter struct {
 	w io.Writer
 }
 
-func (j JSONFormatter) Details(rows [][]Value) {
+func (j JSONFormatter) Details(rows [][]Value) error {
 	var err error
 	m := make(map[string]json.RawMessage, len(rows))
 
@@ -189,16 +279,16 @@ func (j JSONFormatter) Details(rows [][]Value) {
 		}
 		m[row[0].String()], err = json.Marshal(row[1])
 		if err != nil {
-			panic(err)
+			return err
 		}
 	}
 
 	encoder := json.NewEncoder(j.w)
 	encoder.SetIndent("", "  ")
-	_ = encoder.Encode(m)
+	return encoder.Encode(m)
 }
 
-func (j JSONFormatter) Table(header []string, rows [][]Value) {
+func (j JSONFormatter) Table(header []string, rows [][]Value) error {
 	var err error
 	m := make([]map[string]json.RawMessage, 0, len(rows))
 
@@ -207,7 +297,7 @@ func (j JSONFormatter) Table(header []string, rows [][]Value) {
 		for i := range header {
 			r[header[i]], err = json.Marshal(row[i])
 			if err != nil {
-				panic(err)
+				return err
 			}
 		}
 		m = append(m, r)
@@ -215,5 +305,5 @@ func (j JSONFormatter) Table(header []string, rows [][]Value) {
 
 	encoder := json.NewEncoder(j.w)
 	encoder.SetIndent("", "  ")
-	_ = encoder.Encode(m)
+	return encoder.Encode(m)
 }
@@ -2,7 +2,10 @@ package format
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
+
+	"github.com/spf13/cobra"
 )
 
 func TestJSONFormatterDetails(t *testing.T) {
@@ -58,3 +61,190 @@ func TestJSONFormatterTable(t *testing.T) {
 		t.Errorf("expected %q, got %q", expected, actual)
 	}
 }
+
+func TestYAMLFormatterTable(t *testing.T) {
+	var buf bytes.Buffer
+	f := YAMLFormatter{&buf}
+
+	f.Table([]string{"Name", "Is Root"}, [][]Value{
+		{String("Developer"), Bool(true)},
+	})
+
+	expected := `- Name: Developer
+  Is Root: true
+`
+
+	actual := buf.String()
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestCSVFormatterTable(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewFormatter(&buf, "tsv", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.Table([]string{"Name", "Is Root"}, ToValues([][]string{
+		{"Developer", "yes"},
+		{"Operator", "no"},
+	}))
+
+	expected := "Name\tIs Root\nDeveloper\tyes\nOperator\tno\n"
+
+	actual := buf.String()
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestTemplateFormatterTable(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewFormatter(&buf, `template={{.Name}} {{index . "Is Root"}}`, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.Table([]string{"Name", "Is Root"}, [][]Value{
+		{String("Developer"), Bool(true)},
+		{String("Operator"), Bool(false)},
+	})
+
+	expected := "Developer true\nOperator false\n"
+
+	actual := buf.String()
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	if _, err := NewFormatter(&buf, "xml", Options{}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestTemplateFormatterExecutionError(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewFormatter(&buf, `template={{.Name.Length}}`, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Table([]string{"Name"}, [][]Value{{String("Developer")}}); err == nil {
+		t.Error("expected an error for a template that cannot be executed")
+	}
+	if err := f.Details([][]Value{{String("Name"), String("Developer")}}); err == nil {
+		t.Error("expected an error for a template that cannot be executed")
+	}
+}
+
+func TestFormatterFromCommandWithoutFormatFlag(t *testing.T) {
+	if _, err := FormatterFromCommand(&cobra.Command{Use: "list"}); err == nil {
+		t.Error("expected an error for a command without a --format flag")
+	}
+}
+
+func TestOptionsApplyTable(t *testing.T) {
+	filter, err := ParseFilter("name!~^Op")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{Columns: []string{"Size", "name"}, SortBy: "-size", Filters: []Filter{filter}}
+
+	header, rows, err := opts.ApplyTable([]string{"Name", "Size"}, [][]Value{
+		{String("Developer"), Int(2)},
+		{String("Operator"), Int(30)},
+		{String("Admin"), Int(10)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{{"Size", "Name"}, {"10", "Admin"}, {"2", "Developer"}}
+	actual := append([][]string{header}, ValuesToStrings(rows)...)
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	if _, _, err := (Options{SortBy: "Age"}).ApplyTable([]string{"Name"}, nil); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestJSONPathFormatterTable(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewFormatter(&buf, `jsonpath={range [*]}{.Name}={['Is Root']}{"\n"}{end}`, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = f.Table([]string{"Name", "Is Root"}, [][]Value{
+		{String("Developer"), Bool(true)},
+		{String("Operator"), Bool(false)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Developer=true\nOperator=false\n"
+
+	actual := buf.String()
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestJSONPathFormatterRows(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewFormatter(&buf, `jsonpath={.rows[*].Name}`, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = f.Table([]string{"Name", "Is Root"}, [][]Value{
+		{String("Developer"), Bool(true)},
+		{String("Operator"), Bool(false)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Developer Operator\n"
+
+	actual := buf.String()
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	f, err = NewFormatter(&buf, `jsonpath={[*].Size}`, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Table([]string{"Name"}, [][]Value{{String("Developer")}}); err == nil {
+		t.Error("expected an error for a jsonpath matching nothing")
+	}
+}
+
+func TestJQFormatterTable(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewFormatter(&buf, `jq=.[] | select(."Is Root") | .Name`, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = f.Table([]string{"Name", "Is Root"}, [][]Value{
+		{String("Developer"), Bool(true)},
+		{String("Operator"), Bool(false)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Developer\n"
+
+	actual := buf.String()
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
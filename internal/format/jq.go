@@ -0,0 +1,75 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+)
+
+// JQFormatter evaluates a jq expression against the JSON representation of the output,
+// as written by JSONFormatter. Tables are a list of objects and details a single object.
+// Each result is written on its own line, strings as is and other values as compact JSON, like jq -r -c.
+type JQFormatter struct {
+	w    io.Writer
+	code *gojq.Code
+}
+
+func NewJQFormatter(w io.Writer, expression string) (JQFormatter, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return JQFormatter{}, fmt.Errorf("invalid jq expression %q: %w", expression, err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return JQFormatter{}, fmt.Errorf("invalid jq expression %q: %w", expression, err)
+	}
+
+	return JQFormatter{w: w, code: code}, nil
+}
+
+func (j JQFormatter) Details(rows [][]Value) error {
+	doc, err := detailsDocument(rows)
+	if err != nil {
+		return err
+	}
+	return j.execute(doc)
+}
+
+func (j JQFormatter) Table(header []string, rows [][]Value) error {
+	doc, err := tableDocument(header, rows)
+	if err != nil {
+		return err
+	}
+	return j.execute(doc)
+}
+
+func (j JQFormatter) execute(doc interface{}) error {
+	iter := j.code.Run(doc)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := result.(error); ok {
+			return err
+		}
+
+		if s, ok := result.(string); ok {
+			if _, err := fmt.Fprintln(j.w, s); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(j.w, string(data)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPathFormatter evaluates a JSONPath template against the JSON representation of the output,
+// as written by JSONFormatter. Tables are a list of objects and details a single object.
+// The rows of a table can also be addressed as {.rows[*].Name}.
+//
+// Templates follow the syntax used by kubectl: expressions in braces such as {[*].Name} or {.Name},
+// quoted keys for names containing spaces as in {[*]['Is Root']}, string literals such as {"\n"}
+// and {range [*]}...{end} to repeat a part of the template for each result.
+// Strings are written as is, other values as JSON and multiple results are separated by spaces.
+// An expression that matches nothing is an error.
+type JSONPathFormatter struct {
+	w     io.Writer
+	nodes []jsonPathNode
+}
+
+type jsonPathNode struct {
+	text  *string
+	expr  string
+	path  []jsonPathStep
+	body  []jsonPathNode
+	isFor bool
+}
+
+type jsonPathStep struct {
+	key      *string
+	index    *int
+	wildcard bool
+}
+
+func NewJSONPathFormatter(w io.Writer, template string) (JSONPathFormatter, error) {
+	nodes, rest, err := parseJSONPathTemplate(template, false)
+	if err != nil {
+		return JSONPathFormatter{}, fmt.Errorf("invalid jsonpath %q: %w", template, err)
+	}
+	if rest != "" {
+		return JSONPathFormatter{}, fmt.Errorf("invalid jsonpath %q: {end} without {range}", template)
+	}
+	return JSONPathFormatter{w: w, nodes: nodes}, nil
+}
+
+func (j JSONPathFormatter) Details(rows [][]Value) error {
+	doc, err := detailsDocument(rows)
+	if err != nil {
+		return err
+	}
+	return j.execute(doc)
+}
+
+func (j JSONPathFormatter) Table(header []string, rows [][]Value) error {
+	doc, err := tableDocument(header, rows)
+	if err != nil {
+		return err
+	}
+	return j.execute(doc)
+}
+
+func (j JSONPathFormatter) execute(doc interface{}) error {
+	var buf bytes.Buffer
+	if err := executeJSONPath(&buf, j.nodes, doc); err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := j.w.Write(buf.Bytes())
+	return err
+}
+
+func executeJSONPath(buf *bytes.Buffer, nodes []jsonPathNode, current interface{}) error {
+	for _, node := range nodes {
+		switch {
+		case node.text != nil:
+			buf.WriteString(*node.text)
+		case node.isFor:
+			for _, value := range evaluateJSONPath(node.path, current) {
+				if err := executeJSONPath(buf, node.body, value); err != nil {
+					return err
+				}
+			}
+		default:
+			values := evaluateJSONPath(node.path, current)
+			if len(values) == 0 {
+				return fmt.Errorf("jsonpath {%s} matched nothing", node.expr)
+			}
+			for i, value := range values {
+				if i > 0 {
+					buf.WriteByte(' ')
+				}
+				if s, ok := value.(string); ok {
+					buf.WriteString(s)
+					continue
+				}
+				data, err := json.Marshal(value)
+				if err != nil {
+					return err
+				}
+				buf.Write(data)
+			}
+		}
+	}
+	return nil
+}
+
+func evaluateJSONPath(path []jsonPathStep, current interface{}) []interface{} {
+	values := []interface{}{current}
+	for _, step := range path {
+		var next []interface{}
+		for _, value := range values {
+			switch v := value.(type) {
+			case map[string]interface{}:
+				switch {
+				case step.wildcard:
+					keys := make([]string, 0, len(v))
+					for key := range v {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, v[key])
+					}
+				case step.key != nil:
+					if field, ok := v[*step.key]; ok {
+						next = append(next, field)
+					}
+				}
+			case []interface{}:
+				switch {
+				case step.wildcard:
+					next = append(next, v...)
+				case step.key != nil && *step.key == "rows":
+					next = append(next, v)
+				case step.index != nil:
+					i := *step.index
+					if i < 0 {
+						i += len(v)
+					}
+					if i >= 0 && i < len(v) {
+						next = append(next, v[i])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// parseJSONPathTemplate parses nodes until the end of the template, or until {end} if inRange is set.
+// It returns the remaining template after {end}.
+func parseJSONPathTemplate(template string, inRange bool) ([]jsonPathNode, string, error) {
+	var nodes []jsonPathNode
+	for template != "" {
+		start := strings.IndexByte(template, '{')
+		if start == -1 {
+			text := template
+			nodes = append(nodes, jsonPathNode{text: &text})
+			break
+		}
+		if start > 0 {
+			text := template[:start]
+			nodes = append(nodes, jsonPathNode{text: &text})
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+		if end == -1 {
+			return nil, "", fmt.Errorf("unclosed {")
+		}
+		expr := strings.TrimSpace(template[start+1 : start+end])
+		template = template[start+end+1:]
+
+		switch {
+		case expr == "end":
+			if !inRange {
+				return nodes, "{end}" + template, nil
+			}
+			return nodes, template, nil
+		case strings.HasPrefix(expr, "range "):
+			path, err := parseJSONPath(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, "", err
+			}
+			body, rest, err := parseJSONPathTemplate(template, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, body: body, isFor: true})
+			template = rest
+		case strings.HasPrefix(expr, `"`):
+			text, err := strconv.Unquote(expr)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid string literal %s", expr)
+			}
+			nodes = append(nodes, jsonPathNode{text: &text})
+		case strings.HasPrefix(expr, "'"):
+			text := strings.TrimSuffix(strings.TrimPrefix(expr, "'"), "'")
+			nodes = append(nodes, jsonPathNode{text: &text})
+		default:
+			path, err := parseJSONPath(expr)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, expr: expr})
+		}
+	}
+
+	if inRange {
+		return nil, "", fmt.Errorf("{range} without {end}")
+	}
+	return nodes, "", nil
+}
+
+func parseJSONPath(expr string) ([]jsonPathStep, error) {
+	expr = strings.TrimPrefix(strings.TrimPrefix(expr, "$"), "@")
+
+	var path []jsonPathStep
+	for expr != "" {
+		switch expr[0] {
+		case '.':
+			expr = expr[1:]
+			if expr == "" || expr[0] == '[' {
+				continue
+			}
+			if expr[0] == '*' {
+				path = append(path, jsonPathStep{wildcard: true})
+				expr = expr[1:]
+				continue
+			}
+			end := strings.IndexAny(expr, ".[")
+			if end == -1 {
+				end = len(expr)
+			}
+			key := expr[:end]
+			path = append(path, jsonPathStep{key: &key})
+			expr = expr[end:]
+		case '[':
+			end := strings.IndexByte(expr, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed [ in %q", expr)
+			}
+			subscript := strings.TrimSpace(expr[1:end])
+			expr = expr[end+1:]
+
+			switch {
+			case subscript == "*":
+				path = append(path, jsonPathStep{wildcard: true})
+			case len(subscript) >= 2 && (subscript[0] == '\'' || subscript[0] == '"') && subscript[len(subscript)-1] == subscript[0]:
+				key := subscript[1 : len(subscript)-1]
+				path = append(path, jsonPathStep{key: &key})
+			default:
+				index, err := strconv.Atoi(subscript)
+				if err != nil {
+					return nil, fmt.Errorf("unsupported subscript [%s]", subscript)
+				}
+				path = append(path, jsonPathStep{index: &index})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q, expected . or [", expr)
+		}
+	}
+	return path, nil
+}
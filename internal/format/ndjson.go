@@ -0,0 +1,37 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// NDJSONFormatter writes each table row as a JSON object on its own line, and details as a single JSON object.
+type NDJSONFormatter struct {
+	w io.Writer
+}
+
+func (n NDJSONFormatter) Details(rows [][]Value) error {
+	m := make(map[string]Value, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		m[row[0].String()] = row[1]
+	}
+
+	return json.NewEncoder(n.w).Encode(m)
+}
+
+func (n NDJSONFormatter) Table(header []string, rows [][]Value) error {
+	encoder := json.NewEncoder(n.w)
+	for _, row := range rows {
+		r := make(map[string]Value, len(header))
+		for i := range header {
+			r[header[i]] = row[i]
+		}
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
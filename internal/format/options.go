@@ -0,0 +1,202 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Options selects, orders and filters the output of a formatter.
+type Options struct {
+	// Columns are the columns, or detail fields, to include in the given order. All are included if empty.
+	Columns []string
+	// SortBy is the column to sort table rows by. It is sorted in descending order if prefixed with "-".
+	SortBy string
+	// NoHeaders leaves out the header of text and CSV tables.
+	NoHeaders bool
+	// Filters are the conditions a table row must match to be included.
+	Filters []Filter
+}
+
+// Filter matches table rows where the value of Column matches Pattern, or does not match it if Negate is set.
+type Filter struct {
+	Column  string
+	Pattern *regexp.Regexp
+	Negate  bool
+}
+
+// ParseFilter parses a filter in the form column~regex, or column!~regex to exclude matching rows.
+func ParseFilter(s string) (Filter, error) {
+	column, pattern, found := strings.Cut(s, "~")
+	if !found || column == "" {
+		return Filter{}, fmt.Errorf("invalid filter %q, expected <column>~<regex> or <column>!~<regex>", s)
+	}
+
+	var negate bool
+	if strings.HasSuffix(column, "!") {
+		column, negate = strings.TrimSuffix(column, "!"), true
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Filter{}, fmt.Errorf("invalid filter %q: %w", s, err)
+	}
+
+	return Filter{Column: column, Pattern: re, Negate: negate}, nil
+}
+
+// OptionsFromCommand returns the options given with the --columns, --sort-by, --no-headers and --filter flags of cmd.
+// Flags which are not defined on cmd are left at their zero value.
+func OptionsFromCommand(cmd *cobra.Command) (Options, error) {
+	var opts Options
+	flags := cmd.Flags()
+
+	if flags.Lookup("columns") != nil {
+		opts.Columns, _ = flags.GetStringSlice("columns")
+	}
+	if flags.Lookup("sort-by") != nil {
+		opts.SortBy, _ = flags.GetString("sort-by")
+	}
+	if flags.Lookup("no-headers") != nil {
+		opts.NoHeaders, _ = flags.GetBool("no-headers")
+	}
+	if flags.Lookup("filter") != nil {
+		filters, _ := flags.GetStringArray("filter")
+		for _, s := range filters {
+			filter, err := ParseFilter(s)
+			if err != nil {
+				return Options{}, err
+			}
+			opts.Filters = append(opts.Filters, filter)
+		}
+	}
+
+	return opts, nil
+}
+
+// ApplyTable returns the header and rows with the rows filtered and sorted, and only the selected columns included.
+func (o Options) ApplyTable(header []string, rows [][]Value) ([]string, [][]Value, error) {
+	for _, filter := range o.Filters {
+		i, err := columnIndex(header, filter.Column)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var filtered [][]Value
+		for _, row := range rows {
+			if filter.Pattern.MatchString(valueString(row[i])) != filter.Negate {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = filtered
+	}
+
+	if o.SortBy != "" {
+		column, descending := strings.CutPrefix(o.SortBy, "-")
+		i, err := columnIndex(header, column)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows = append([][]Value{}, rows...)
+		sort.SliceStable(rows, func(a, b int) bool {
+			if descending {
+				return lessValue(rows[b][i], rows[a][i])
+			}
+			return lessValue(rows[a][i], rows[b][i])
+		})
+	}
+
+	if len(o.Columns) == 0 {
+		return header, rows, nil
+	}
+
+	indexes := make([]int, len(o.Columns))
+	selectedHeader := make([]string, len(o.Columns))
+	for j, column := range o.Columns {
+		i, err := columnIndex(header, column)
+		if err != nil {
+			return nil, nil, err
+		}
+		indexes[j] = i
+		selectedHeader[j] = header[i]
+	}
+
+	selectedRows := make([][]Value, len(rows))
+	for r, row := range rows {
+		selectedRows[r] = make([]Value, len(indexes))
+		for j, i := range indexes {
+			selectedRows[r][j] = row[i]
+		}
+	}
+
+	return selectedHeader, selectedRows, nil
+}
+
+// ApplyDetails returns only the selected fields of the details, in the order they were selected.
+func (o Options) ApplyDetails(rows [][]Value) ([][]Value, error) {
+	if len(o.Columns) == 0 {
+		return rows, nil
+	}
+
+	var fields []string
+	for _, row := range rows {
+		if len(row) > 0 {
+			fields = append(fields, valueString(row[0]))
+		} else {
+			fields = append(fields, "")
+		}
+	}
+
+	selected := make([][]Value, 0, len(o.Columns))
+	for _, column := range o.Columns {
+		i, err := columnIndex(fields, column)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, rows[i])
+	}
+
+	return selected, nil
+}
+
+// columnIndex returns the index of column in header, ignoring case.
+func columnIndex(header []string, column string) (int, error) {
+	for i := range header {
+		if strings.EqualFold(header[i], column) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown column %q, valid columns: %s", column, strings.Join(header, ", "))
+}
+
+func valueString(v Value) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// lessValue orders numbers numerically and everything else as case-insensitive strings.
+func lessValue(a, b Value) bool {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return x < y
+		}
+	}
+	return strings.ToLower(valueString(a)) < strings.ToLower(valueString(b))
+}
+
+func number(v Value) (float64, bool) {
+	switch v := v.(type) {
+	case Int:
+		return float64(v), true
+	case Float:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,75 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// TemplateFormatter executes a Go template once for each table row, or once for the details.
+// The template is given a map from column or field name to value, so fields can be accessed as {{.Name}}
+// or, for names containing spaces, {{index . "Is Root"}}. A newline is added after each execution.
+type TemplateFormatter struct {
+	w        io.Writer
+	template *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, v []interface{}) string {
+		s := make([]string, len(v))
+		for i := range v {
+			s[i] = fmt.Sprint(v[i])
+		}
+		return strings.Join(s, sep)
+	},
+}
+
+func NewTemplateFormatter(w io.Writer, text string) (TemplateFormatter, error) {
+	t, err := template.New("format").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return TemplateFormatter{}, fmt.Errorf("invalid template: %w", err)
+	}
+	return TemplateFormatter{w: w, template: t}, nil
+}
+
+func (t TemplateFormatter) Details(rows [][]Value) error {
+	m, err := detailsDocument(rows)
+	if err != nil {
+		return err
+	}
+
+	return t.execute(m)
+}
+
+func (t TemplateFormatter) Table(header []string, rows [][]Value) error {
+	m, err := tableDocument(header, rows)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range m {
+		if err := t.execute(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t TemplateFormatter) execute(data interface{}) error {
+	var buf bytes.Buffer
+	if err := t.template.Execute(&buf, data); err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := t.w.Write(buf.Bytes())
+	return err
+}
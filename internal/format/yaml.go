@@ -0,0 +1,54 @@
+package format
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// YAMLFormatter writes details as a YAML mapping and tables as a sequence of mappings, keeping the order of the fields.
+type YAMLFormatter struct {
+	w io.Writer
+}
+
+func (y YAMLFormatter) Details(rows [][]Value) error {
+	m := make(yaml.MapSlice, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		value, err := nativeValue(row[1])
+		if err != nil {
+			return err
+		}
+		m = append(m, yaml.MapItem{Key: row[0].String(), Value: value})
+	}
+
+	return y.encode(m)
+}
+
+func (y YAMLFormatter) Table(header []string, rows [][]Value) error {
+	m := make([]yaml.MapSlice, 0, len(rows))
+	for _, row := range rows {
+		r := make(yaml.MapSlice, len(header))
+		for i := range header {
+			value, err := nativeValue(row[i])
+			if err != nil {
+				return err
+			}
+			r[i] = yaml.MapItem{Key: header[i], Value: value}
+		}
+		m = append(m, r)
+	}
+
+	return y.encode(m)
+}
+
+func (y YAMLFormatter) encode(v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = y.w.Write(data)
+	return err
+}
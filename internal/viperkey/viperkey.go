@@ -4,6 +4,7 @@ const (
 	Address           = "address"
 	Token             = "token"
 	TokenFile         = "token-file"
+	TokenCommand      = "token_command"
 	CACertificateFile = "ca-certificate-file"
 	CACertificate     = "ca_certificate"
 	Insecure          = "insecure"